@@ -4,18 +4,31 @@ import (
 	"context"
 	"fmt"
 	"golang-rest-api/config"
+	handlerAuth "golang-rest-api/internal/handler/auth"
+	handlerRole "golang-rest-api/internal/handler/role"
 	handlerUser "golang-rest-api/internal/handler/user"
+	modelRole "golang-rest-api/internal/model/role"
 	modelUser "golang-rest-api/internal/model/user"
+	repoRole "golang-rest-api/internal/repository/role"
 	repoUser "golang-rest-api/internal/repository/user"
+	serviceAuth "golang-rest-api/internal/service/auth"
+	serviceRole "golang-rest-api/internal/service/role"
 	serviceUser "golang-rest-api/internal/service/user"
+	"golang-rest-api/pkg/crypter"
 	"golang-rest-api/pkg/database"
 	httpmiddleware "golang-rest-api/pkg/http_middleware"
 	httpserver "golang-rest-api/pkg/http_server"
 	"golang-rest-api/pkg/jwt"
+	"golang-rest-api/pkg/lockout"
 	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/mailer"
+	passwordpolicy "golang-rest-api/pkg/password_policy"
+	"golang-rest-api/pkg/totp"
+	"golang-rest-api/pkg/validator"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
@@ -33,15 +46,63 @@ func main() {
 		AppVersion: "v0.0.0",
 	})
 
-	jwtGenerator := jwt.NewJWTGenerator(
+	passwordStrengthRule := passwordpolicy.StrengthRule{
+		MinLength:     config.Get().PasswordMinLength,
+		RequireUpper:  config.Get().PasswordRequireUpper,
+		RequireLower:  config.Get().PasswordRequireLower,
+		RequireDigit:  config.Get().PasswordRequireDigit,
+		RequireSymbol: config.Get().PasswordRequireSymbol,
+	}
+	validator.SetPasswordStrengthRule(passwordStrengthRule)
+
+	passwordPolicyOptions := []passwordpolicy.PasswordPolicyOption{
+		passwordpolicy.WithStrengthRule(passwordStrengthRule),
+	}
+	if len(config.Get().PasswordDenyListFile) > 0 {
+		deniedPasswords, err := passwordpolicy.LoadDenyListFile(config.Get().PasswordDenyListFile)
+		if err != nil {
+			log.Fatal(context.Background(), "Error load PASSWORD_DENY_LIST_FILE: ", err)
+		}
+
+		passwordPolicyOptions = append(passwordPolicyOptions, passwordpolicy.WithDenyList(deniedPasswords))
+	}
+
+	for _, name := range config.DeprecatedEnvs() {
+		log.Info(context.Background(), fmt.Sprintf("%s is deprecated, use the env without RSA_ instead", name))
+	}
+
+	// token issued without requested audience must still be accepted by this API
+	if len(config.Get().JWTAllowedAudiences) > 0 && !slices.Contains(config.Get().JWTAllowedAudiences, config.Get().JWTDefaultAudience) {
+		log.Fatal(context.Background(), "Error invalid JWT_DEFAULT_AUDIENCE: ", fmt.Errorf("%q is not in JWT_ALLOWED_AUDIENCES", config.Get().JWTDefaultAudience))
+	}
+
+	jwtSigningMethod := jwt.JWTSigningMethodName(config.Get().JWTSigningMethod)
+	jwtGeneratorOptions := []jwt.JWTGeneratorOptions{
 		jwt.JWTGeneratorWithIssuer(config.Get().AppName),
-		jwt.JWTGeneratorWithSigningMethod(jwt.JWTSigningMethodNameRS256, config.Get().JWTRSAPrivateKey),
-	)
+		jwt.JWTGeneratorWithSigningKey(config.Get().JWTKeyID, jwtSigningMethod, config.Get().JWTPrivateKey),
+		jwt.JWTGeneratorWithMFAPendingExpireDuration(config.Get().MFATokenExpireDuration),
+	}
+	if len(config.Get().JWTDefaultAudience) > 0 {
+		jwtGeneratorOptions = append(jwtGeneratorOptions, jwt.JWTGeneratorWithAudience(config.Get().JWTDefaultAudience))
+	}
+
+	jwtGenerator := jwt.NewJWTGenerator(jwtGeneratorOptions...)
 
-	jwtValidator := jwt.NewJWTParser(
-		jwt.JWTParserWithSigningMethod(jwt.JWTSigningMethodNameRS256, config.Get().JWTRSAPublicKey),
+	jwtParserOptions := []jwt.JWTParserOptions{
+		jwt.JWTParserWithVerificationKey(config.Get().JWTKeyID, jwtSigningMethod, config.Get().JWTPublicKey),
 		jwt.JWTParserWithValidIssuer(config.Get().AppName),
-	)
+		jwt.JWTParserWithValidAudience(config.Get().JWTAllowedAudiences...),
+	}
+	for _, previousKey := range config.Get().JWTPreviousPublicKeys {
+		keyID, previousSigningMethod, publicKey, err := jwt.ParsePreviousVerificationKey(previousKey, jwtSigningMethod)
+		if err != nil {
+			log.Fatal(context.Background(), "Error invalid JWT_PREVIOUS_PUBLIC_KEYS: ", err)
+		}
+
+		jwtParserOptions = append(jwtParserOptions, jwt.JWTParserWithVerificationKey(keyID, previousSigningMethod, publicKey))
+	}
+
+	jwtValidator := jwt.NewJWTParser(jwtParserOptions...)
 
 	r := chi.NewRouter()
 	r.Use(httpmiddleware.PanicRecoverer)
@@ -54,29 +115,184 @@ func main() {
 		database.WithPostgresDBName(config.Get().DatabaseName),
 	)
 
+	var jwtRevocationStore jwt.JWTRevocationStore = jwt.NewPostgresRevocationStore(posgresDB)
+	if config.Get().JWTRevocationStore == "memory" {
+		jwtRevocationStore = jwt.NewInMemoryRevocationStore()
+	}
+
+	var loginAttemptStore lockout.Store = lockout.NewPostgresStore(posgresDB)
+	if config.Get().LoginLockoutStore == "memory" {
+		loginAttemptStore = lockout.NewInMemoryStore()
+	}
+
+	var mailSender mailer.Mailer = mailer.NewFileMailer(config.Get().MailerOutboxDir)
+	if config.Get().MailerDriver == "smtp" {
+		mailSender = mailer.NewSMTPMailer(
+			mailer.WithSMTPHost(config.Get().SMTPHost),
+			mailer.WithSMTPPort(config.Get().SMTPPort),
+			mailer.WithSMTPAuth(config.Get().SMTPUsername, config.Get().SMTPPassword),
+			mailer.WithSMTPFrom(config.Get().MailerFrom),
+		)
+	}
+
+	var mfaEncrypter crypter.Encrypter
+	if len(config.Get().MFAEncryptionKey) > 0 {
+		aesGCMEncrypter, err := crypter.NewAESGCMEncrypter(config.Get().MFAEncryptionKey)
+		if err != nil {
+			log.Fatal(context.Background(), "Error invalid MFA_ENCRYPTION_KEY: ", err)
+		}
+
+		mfaEncrypter = aesGCMEncrypter
+	} else if config.Get().MFARequiredForAdmin {
+		log.Fatal(context.Background(), "Error MFA_ENCRYPTION_KEY is required when MFA_REQUIRED_FOR_ADMIN is true", fmt.Errorf("mfa encryption key not set"))
+	}
+
 	// repository
 	userRepo := repoUser.NewUserRepo(posgresDB)
+	sessionRepo := repoUser.NewSessionRepo(posgresDB)
+	roleRepo := repoRole.NewRoleRepo(posgresDB)
+	passwordResetTokenRepo := repoUser.NewPasswordResetTokenRepo(posgresDB)
+	emailVerificationTokenRepo := repoUser.NewEmailVerificationTokenRepo(posgresDB)
+	recoveryCodeRepo := repoUser.NewRecoveryCodeRepo(posgresDB)
 
 	// service
 	userService := serviceUser.NewUserService(
 		serviceUser.WithTxHandler(posgresDB),
+		serviceUser.WithPasswordPolicy(passwordpolicy.NewPasswordPolicy(passwordPolicyOptions...)),
+		serviceUser.WithCrypter(crypter.New(
+			crypter.WithAlgorithm(config.Get().PasswordHashAlgorithm),
+			crypter.WithBcryptCost(config.Get().BcryptCost),
+			crypter.WithArgon2Params(crypter.Argon2Params{
+				Memory:      config.Get().Argon2Memory,
+				Iterations:  config.Get().Argon2Iterations,
+				Parallelism: config.Get().Argon2Parallelism,
+				SaltLength:  16,
+				KeyLength:   32,
+			}),
+			crypter.WithArgon2Concurrency(config.Get().Argon2MaxConcurrency),
+		)),
 		serviceUser.WithUserRepo(userRepo),
+		serviceUser.WithSessionRepo(sessionRepo),
+		serviceUser.WithRoleRepo(roleRepo),
 		serviceUser.WithJWTGenerator(jwtGenerator),
+		serviceUser.WithJWTParser(jwtValidator),
+		serviceUser.WithJWTRevocationStore(jwtRevocationStore),
+		serviceUser.WithAllowedAudiences(config.Get().JWTAllowedAudiences),
+		serviceUser.WithPasswordResetTokenRepo(passwordResetTokenRepo),
+		serviceUser.WithMailer(mailSender),
+		serviceUser.WithPasswordResetURL(config.Get().PasswordResetURL),
+		serviceUser.WithPasswordResetTokenExpireDuration(config.Get().PasswordResetTokenExpireDuration),
+		serviceUser.WithEmailVerificationTokenRepo(emailVerificationTokenRepo),
+		serviceUser.WithEmailVerificationURL(config.Get().EmailVerificationURL),
+		serviceUser.WithEmailVerificationTokenExpireDuration(config.Get().EmailVerificationTokenExpireDuration),
+		serviceUser.WithEmailVerificationRequired(config.Get().EmailVerificationRequired),
+		serviceUser.WithRecoveryCodeRepo(recoveryCodeRepo),
+		serviceUser.WithMFA(totp.NewTOTP(totp.WithIssuer(config.Get().AppName)), mfaEncrypter),
+		serviceUser.WithLoginLockout(
+			loginAttemptStore,
+			lockout.Policy{
+				MaxAttempts:      config.Get().LoginMaxAttemptsPerUsername,
+				BaseLockDuration: config.Get().LoginLockoutBaseDuration,
+				MaxLockDuration:  config.Get().LoginLockoutMaxDuration,
+				ResetAfter:       config.Get().LoginAttemptResetAfter,
+			},
+			lockout.Policy{
+				MaxAttempts:      config.Get().LoginMaxAttemptsPerIP,
+				BaseLockDuration: config.Get().LoginLockoutBaseDuration,
+				MaxLockDuration:  config.Get().LoginLockoutMaxDuration,
+				ResetAfter:       config.Get().LoginAttemptResetAfter,
+			},
+		),
+		serviceUser.WithMailRateLimit(config.Get().MailCooldown, lockout.Policy{
+			MaxAttempts:      config.Get().MailMaxRequestsPerIP,
+			BaseLockDuration: time.Hour,
+			MaxLockDuration:  time.Hour,
+			ResetAfter:       time.Hour,
+		}),
+		serviceUser.WithLoginDelay(lockout.Policy{
+			MaxAttempts:      config.Get().LoginDelayAfterAttemptsPerUsername,
+			BaseLockDuration: config.Get().LoginDelayBaseDuration,
+			MaxLockDuration:  config.Get().LoginDelayMaxDuration,
+			ResetAfter:       config.Get().LoginAttemptResetAfter,
+		}),
+	)
+
+	roleService := serviceRole.NewRoleService(
+		serviceRole.WithTxHandler(posgresDB),
+		serviceRole.WithRoleRepo(roleRepo),
+		serviceRole.WithUserRepo(userRepo),
+	)
+
+	authService := serviceAuth.NewAuthService(
+		serviceAuth.WithJWTParser(jwtValidator),
+		serviceAuth.WithJWTRevocationStore(jwtRevocationStore),
+		serviceAuth.WithSessionRepo(sessionRepo),
+		serviceAuth.WithUserRepo(userRepo),
 	)
 
 	// handler
 	userHandler := handlerUser.NewUserHandler(userService)
+	authHandler := handlerAuth.NewAuthHandler(jwtValidator, authService)
+	roleHandler := handlerRole.NewRoleHandler(roleService)
 
 	// router
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"), //The url pointing to API definition
 	))
 
+	r.Method(http.MethodGet, "/.well-known/jwks.json", httpserver.HandlerWithError(authHandler.JWKS))
+	r.With(httpmiddleware.BasicClientAuth(config.Get().IntrospectionClients)).
+		Method(http.MethodPost, "/api/v1/auth/introspect", httpserver.HandlerWithError(authHandler.Introspect))
 	r.Method(http.MethodPost, "/api/v1/user/login", httpserver.HandlerWithError(userHandler.Login))
+	r.Method(http.MethodPost, "/api/v1/user/login/mfa", httpserver.HandlerWithError(userHandler.LoginMFA))
+	r.Method(http.MethodPost, "/api/v1/user/token/refresh", httpserver.HandlerWithError(userHandler.RefreshToken))
+	r.Method(http.MethodPost, "/api/v1/user/password/forgot", httpserver.HandlerWithError(userHandler.ForgotPassword))
+	r.Method(http.MethodPost, "/api/v1/user/password/reset", httpserver.HandlerWithError(userHandler.ResetPassword))
+	r.Method(http.MethodPost, "/api/v1/user/email/verify", httpserver.HandlerWithError(userHandler.VerifyEmail))
+	r.Method(http.MethodPost, "/api/v1/user/email/verification/resend", httpserver.HandlerWithError(userHandler.ResendEmailVerification))
 	r.Group(func(r chi.Router) {
-		r.Use(httpmiddleware.JWTAuthUser(jwtValidator, modelUser.AccessTokenCookieName))
-		r.Method(http.MethodPost, "/api/v1/user", httpserver.HandlerWithError(userHandler.CreateUser))
+		r.Use(httpmiddleware.JWTAuthUser(
+			jwtValidator,
+			modelUser.AccessTokenCookieName,
+			httpmiddleware.JWTAuthUserWithRevocationStore(jwtRevocationStore),
+			httpmiddleware.JWTAuthUserWithActiveSubjectCheck(userRepo.IsUserActive),
+		))
+		r.Method(http.MethodPost, "/api/v1/user/logout", httpserver.HandlerWithError(userHandler.Logout))
 		r.Method(http.MethodGet, "/api/v1/user/profile", httpserver.HandlerWithError(userHandler.UserProfile))
+		r.Method(http.MethodPatch, "/api/v1/user/profile", httpserver.HandlerWithError(userHandler.UpdateProfile))
+		r.Method(http.MethodDelete, "/api/v1/user/profile", httpserver.HandlerWithError(userHandler.DeleteAccount))
+		r.Method(http.MethodPut, "/api/v1/user/password", httpserver.HandlerWithError(userHandler.ChangePassword))
+		r.Method(http.MethodPost, "/api/v1/user/email/verification", httpserver.HandlerWithError(userHandler.SendEmailVerification))
+		r.Method(http.MethodPost, "/api/v1/user/mfa/enroll", httpserver.HandlerWithError(userHandler.EnrollMFA))
+		r.Method(http.MethodPost, "/api/v1/user/mfa/confirm", httpserver.HandlerWithError(userHandler.ConfirmMFA))
+		r.Method(http.MethodPost, "/api/v1/user/mfa/disable", httpserver.HandlerWithError(userHandler.DisableMFA))
+
+		// admin endpoints
+		r.Group(func(r chi.Router) {
+			if config.Get().MFARequiredForAdmin {
+				r.Use(httpmiddleware.RequireAuthMethod(jwt.AuthMethodOTP))
+			}
+
+			r.With(httpmiddleware.RequirePermission(modelRole.PermissionUserCreate)).
+				Method(http.MethodPost, "/api/v1/user", httpserver.HandlerWithError(userHandler.CreateUser))
+			r.With(httpmiddleware.RequirePermission(modelRole.PermissionUserRead)).
+				Method(http.MethodGet, "/api/v1/users", httpserver.HandlerWithError(userHandler.ListUsers))
+			r.With(httpmiddleware.RequirePermission(modelRole.PermissionUserRead)).
+				Method(http.MethodGet, "/api/v1/user/{id}", httpserver.HandlerWithError(userHandler.UserDetail))
+			r.With(httpmiddleware.RequirePermission(modelRole.PermissionUserDelete)).
+				Method(http.MethodDelete, "/api/v1/user/{id}", httpserver.HandlerWithError(userHandler.DeleteUser))
+			r.With(httpmiddleware.RequirePermission(modelRole.PermissionUserRestore)).
+				Method(http.MethodPost, "/api/v1/user/{id}/restore", httpserver.HandlerWithError(userHandler.RestoreUser))
+
+			r.Group(func(r chi.Router) {
+				r.Use(httpmiddleware.RequirePermission(modelRole.PermissionRoleManage))
+				r.Method(http.MethodPost, "/api/v1/role", httpserver.HandlerWithError(roleHandler.CreateRole))
+				r.Method(http.MethodGet, "/api/v1/roles", httpserver.HandlerWithError(roleHandler.GetRoles))
+				r.Method(http.MethodPost, "/api/v1/role/{name}/permission", httpserver.HandlerWithError(roleHandler.GrantPermission))
+				r.Method(http.MethodPost, "/api/v1/user/{id}/role", httpserver.HandlerWithError(roleHandler.AssignUserRole))
+				r.Method(http.MethodDelete, "/api/v1/user/{id}/role/{name}", httpserver.HandlerWithError(roleHandler.RemoveUserRole))
+			})
+		})
 	})
 
 	httpServer := http.Server{
@@ -2,6 +2,7 @@ package config
 
 import (
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -11,8 +12,94 @@ type envConfig struct {
 	AppPort string `env:"APP_PORT" envDefault:"8080"`
 	AppName string `env:"APP_NAME" envDefault:"golang-rest-api"`
 
+	// JWTSigningMethod one of RS256, RS384, RS512, ES256, ES384, ES512 or EdDSA
+	JWTSigningMethod string `env:"JWT_SIGNING_METHOD" envDefault:"RS256"`
+	JWTKeyID         string `env:"JWT_KEY_ID"`
+	JWTPrivateKey    string `env:"JWT_PRIVATE_KEY"`
+	JWTPublicKey     string `env:"JWT_PUBLIC_KEY"`
+	// Deprecated: JWTRSAPrivateKey and JWTRSAPublicKey are only read when JWT_PRIVATE_KEY or JWT_PUBLIC_KEY is empty
 	JWTRSAPrivateKey string `env:"JWT_RSA_PRIVATE_KEY"`
 	JWTRSAPublicKey  string `env:"JWT_RSA_PUBLIC_KEY"`
+	// JWTPreviousPublicKeys public keys of rotated signing keys in kid:alg:PEM format separated by comma,
+	// alg can be omitted (kid:PEM) when the key was used with the current JWTSigningMethod
+	JWTPreviousPublicKeys []string `env:"JWT_PREVIOUS_PUBLIC_KEYS"`
+	// JWTAllowedAudiences clients which token can be issued for, e.g. web,mobile,
+	// every allowed audience is accepted by this API, audience only restricts which service or client accept the token
+	JWTAllowedAudiences []string `env:"JWT_ALLOWED_AUDIENCES"`
+	// JWTDefaultAudience audience of token when client does not request specific audience,
+	// must be one of JWTAllowedAudiences when JWTAllowedAudiences is set
+	JWTDefaultAudience string `env:"JWT_DEFAULT_AUDIENCE"`
+	// JWTRevocationStore one of postgres or memory
+	JWTRevocationStore string `env:"JWT_REVOCATION_STORE" envDefault:"postgres"`
+
+	// IntrospectionClients trusted clients which can call token introspection in client_id:client_secret format separated by comma
+	IntrospectionClients map[string]string `env:"INTROSPECTION_CLIENTS"`
+
+	// MailerDriver one of file or smtp, file write mail to MailerOutboxDir and should only be used locally
+	MailerDriver    string `env:"MAILER_DRIVER" envDefault:"file"`
+	MailerOutboxDir string `env:"MAILER_OUTBOX_DIR" envDefault:"outbox"`
+	MailerFrom      string `env:"MAILER_FROM"`
+	SMTPHost        string `env:"SMTP_HOST"`
+	SMTPPort        string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername    string `env:"SMTP_USERNAME"`
+	SMTPPassword    string `env:"SMTP_PASSWORD"`
+
+	// PasswordResetURL page which receive password reset token as token query param
+	PasswordResetURL                 string        `env:"PASSWORD_RESET_URL"`
+	PasswordResetTokenExpireDuration time.Duration `env:"PASSWORD_RESET_TOKEN_EXPIRE_DURATION" envDefault:"30m"`
+	// MailCooldown mail requested without login, e.g. password reset, is not sent again to the same user within this duration
+	MailCooldown time.Duration `env:"MAIL_COOLDOWN" envDefault:"5m"`
+	// MailMaxRequestsPerIP mail requested without login allowed per client ip before the ip is limited for an hour, 0 disable the limit
+	MailMaxRequestsPerIP int `env:"MAIL_MAX_REQUESTS_PER_IP" envDefault:"10"`
+
+	// EmailVerificationURL page which receive email verification token as token query param
+	EmailVerificationURL                 string        `env:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationTokenExpireDuration time.Duration `env:"EMAIL_VERIFICATION_TOKEN_EXPIRE_DURATION" envDefault:"24h"`
+	// EmailVerificationRequired reject login of user whose email is not verified, user without email is not rejected
+	EmailVerificationRequired bool `env:"EMAIL_VERIFICATION_REQUIRED" envDefault:"false"`
+
+	// Password policy of new password
+	PasswordMinLength     int  `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordRequireUpper  bool `env:"PASSWORD_REQUIRE_UPPER" envDefault:"true"`
+	PasswordRequireLower  bool `env:"PASSWORD_REQUIRE_LOWER" envDefault:"true"`
+	PasswordRequireDigit  bool `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"true"`
+	PasswordRequireSymbol bool `env:"PASSWORD_REQUIRE_SYMBOL" envDefault:"false"`
+	// PasswordDenyListFile file of rejected passwords, e.g. breached password list, one password per line
+	PasswordDenyListFile string `env:"PASSWORD_DENY_LIST_FILE"`
+
+	// PasswordHashAlgorithm one of argon2id or bcrypt, hash of the other algorithm is rehashed on login
+	PasswordHashAlgorithm string `env:"PASSWORD_HASH_ALGORITHM" envDefault:"argon2id"`
+	BcryptCost            int    `env:"BCRYPT_COST" envDefault:"12"`
+	// Argon2Memory memory used by argon2id in KiB
+	Argon2Memory      uint32 `env:"ARGON2_MEMORY" envDefault:"65536"`
+	Argon2Iterations  uint32 `env:"ARGON2_ITERATIONS" envDefault:"3"`
+	Argon2Parallelism uint8  `env:"ARGON2_PARALLELISM" envDefault:"2"`
+	// Argon2MaxConcurrency argon2id allowed to run at the same time, each one use Argon2Memory, 0 use number of cpu
+	Argon2MaxConcurrency int `env:"ARGON2_MAX_CONCURRENCY" envDefault:"0"`
+
+	// LoginLockoutStore one of postgres or memory
+	LoginLockoutStore string `env:"LOGIN_LOCKOUT_STORE" envDefault:"postgres"`
+	// LoginMaxAttemptsPerUsername failed login allowed before username is locked for the client ip, 0 disable the lock
+	LoginMaxAttemptsPerUsername int `env:"LOGIN_MAX_ATTEMPTS_PER_USERNAME" envDefault:"5"`
+	// LoginDelayAfterAttemptsPerUsername failed login from any client ip allowed before failed login of username is delayed,
+	// delay start at LoginDelayBaseDuration and is doubled on every next failure up to LoginDelayMaxDuration, 0 disable the delay
+	LoginDelayAfterAttemptsPerUsername int           `env:"LOGIN_DELAY_AFTER_ATTEMPTS_PER_USERNAME" envDefault:"5"`
+	LoginDelayBaseDuration             time.Duration `env:"LOGIN_DELAY_BASE_DURATION" envDefault:"1s"`
+	LoginDelayMaxDuration              time.Duration `env:"LOGIN_DELAY_MAX_DURATION" envDefault:"10s"`
+	// LoginMaxAttemptsPerIP failed login allowed before client ip is locked, 0 disable the lock
+	LoginMaxAttemptsPerIP int `env:"LOGIN_MAX_ATTEMPTS_PER_IP" envDefault:"20"`
+	// LoginLockoutBaseDuration first lock duration, doubled on every next failure up to LoginLockoutMaxDuration
+	LoginLockoutBaseDuration time.Duration `env:"LOGIN_LOCKOUT_BASE_DURATION" envDefault:"1m"`
+	LoginLockoutMaxDuration  time.Duration `env:"LOGIN_LOCKOUT_MAX_DURATION" envDefault:"1h"`
+	// LoginAttemptResetAfter failed login is forgotten when there is no failure within this duration
+	LoginAttemptResetAfter time.Duration `env:"LOGIN_ATTEMPT_RESET_AFTER" envDefault:"1h"`
+
+	// MFAEncryptionKey base64 encoded 32 bytes key used to encrypt TOTP secret, MFA is unavailable when empty
+	MFAEncryptionKey string `env:"MFA_ENCRYPTION_KEY"`
+	// MFATokenExpireDuration time given to enter MFA code after password is verified
+	MFATokenExpireDuration time.Duration `env:"MFA_TOKEN_EXPIRE_DURATION" envDefault:"5m"`
+	// MFARequiredForAdmin only allow token obtained using MFA to access admin endpoints, require MFAEncryptionKey
+	MFARequiredForAdmin bool `env:"MFA_REQUIRED_FOR_ADMIN" envDefault:"false"`
 
 	DatabaseHost        string `env:"DATABASE_HOST"`
 	DatabasePort        string `env:"DATABASE_PORT"`
@@ -27,8 +114,9 @@ type envConfig struct {
 }
 
 var (
-	envCfg *envConfig
-	once   sync.Once
+	envCfg         *envConfig
+	once           sync.Once
+	deprecatedEnvs []string
 )
 
 func Get() *envConfig {
@@ -42,7 +130,25 @@ func LoadEnvConfig() {
 		if err := env.Parse(c); err != nil {
 			panic(err)
 		}
+		applyDeprecatedEnv(c)
 		envCfg = c
 		// cfg.Version = buildutil.Version // TODO: get when build
 	})
 }
+
+// DeprecatedEnvs return name of deprecated env which value is used, should be logged on startup
+func DeprecatedEnvs() []string {
+	return deprecatedEnvs
+}
+
+func applyDeprecatedEnv(c *envConfig) {
+	if len(c.JWTPrivateKey) == 0 && len(c.JWTRSAPrivateKey) > 0 {
+		c.JWTPrivateKey = c.JWTRSAPrivateKey
+		deprecatedEnvs = append(deprecatedEnvs, "JWT_RSA_PRIVATE_KEY")
+	}
+
+	if len(c.JWTPublicKey) == 0 && len(c.JWTRSAPublicKey) > 0 {
+		c.JWTPublicKey = c.JWTRSAPublicKey
+		deprecatedEnvs = append(deprecatedEnvs, "JWT_RSA_PUBLIC_KEY")
+	}
+}
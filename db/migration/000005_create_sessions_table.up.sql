@@ -0,0 +1,15 @@
+BEGIN;
+  CREATE TABLE sessions(
+      id uuid NOT NULL PRIMARY KEY,
+      family_id uuid NOT NULL,
+      user_id uuid NOT NULL REFERENCES users(id),
+      expires_at timestamptz NOT NULL,
+      rotated_at timestamptz NULL,
+      replaced_by uuid NULL,
+      revoked_at timestamptz NULL,
+      created_at timestamptz NOT NULL DEFAULT NOW()
+  );
+
+  CREATE INDEX sessions_family_id_idx ON sessions (family_id);
+  CREATE INDEX sessions_user_id_idx ON sessions (user_id);
+END;
\ No newline at end of file
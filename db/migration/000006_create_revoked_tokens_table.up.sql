@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE revoked_tokens(
+      token_id varchar(255) NOT NULL PRIMARY KEY,
+      expires_at timestamptz NOT NULL,
+      created_at timestamptz NOT NULL DEFAULT NOW()
+  );
+
+  CREATE INDEX revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+END;
\ No newline at end of file
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE IF EXISTS user_roles;
+  DROP TABLE IF EXISTS roles;
+END;
\ No newline at end of file
@@ -0,0 +1,21 @@
+BEGIN;
+  CREATE TABLE roles(
+      id uuid NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+      name varchar(50) NOT NULL,
+      description varchar(255) NOT NULL DEFAULT '',
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      created_by varchar(255),
+      CONSTRAINT role_unique_name UNIQUE (name)
+  );
+
+  CREATE TABLE user_roles(
+      user_id uuid NOT NULL REFERENCES users(id),
+      role_id uuid NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      created_by varchar(255),
+      PRIMARY KEY (user_id, role_id)
+  );
+
+  INSERT INTO roles (name, description, created_by)
+    VALUES ('admin', 'Administrator', 'migration');
+END;
\ No newline at end of file
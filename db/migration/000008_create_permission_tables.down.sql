@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE IF EXISTS role_permissions;
+  DROP TABLE IF EXISTS permissions;
+END;
\ No newline at end of file
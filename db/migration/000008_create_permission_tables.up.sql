@@ -0,0 +1,28 @@
+BEGIN;
+  CREATE TABLE permissions(
+      id uuid NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+      name varchar(100) NOT NULL,
+      description varchar(255) NOT NULL DEFAULT '',
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      created_by varchar(255),
+      CONSTRAINT permission_unique_name UNIQUE (name)
+  );
+
+  CREATE TABLE role_permissions(
+      role_id uuid NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+      permission_id uuid NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      created_by varchar(255),
+      PRIMARY KEY (role_id, permission_id)
+  );
+
+  INSERT INTO permissions (name, description, created_by)
+    VALUES
+      ('user:create', 'Create user', 'migration'),
+      ('role:manage', 'Manage roles, role permissions and user roles', 'migration');
+
+  INSERT INTO role_permissions (role_id, permission_id, created_by)
+    SELECT r.id, p.id, 'migration'
+    FROM roles r CROSS JOIN permissions p
+    WHERE r.name = 'admin';
+END;
\ No newline at end of file
@@ -0,0 +1,3 @@
+BEGIN;
+    DROP TABLE IF EXISTS password_reset_tokens;
+COMMIT;
\ No newline at end of file
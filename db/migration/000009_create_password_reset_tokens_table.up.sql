@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE password_reset_tokens(
+      id uuid NOT NULL PRIMARY KEY,
+      user_id uuid NOT NULL REFERENCES users(id),
+      token_hash varchar(64) NOT NULL,
+      expires_at timestamptz NOT NULL,
+      used_at timestamptz NULL,
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      CONSTRAINT password_reset_token_unique_token_hash UNIQUE (token_hash)
+  );
+
+  CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens (user_id);
+END;
\ No newline at end of file
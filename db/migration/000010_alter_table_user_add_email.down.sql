@@ -0,0 +1,8 @@
+BEGIN;
+  ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS user_unique_email;
+
+  ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified_at,
+    DROP COLUMN IF EXISTS email;
+END;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN;
+  ALTER TABLE users
+    ADD COLUMN email varchar(255) NULL,
+    ADD COLUMN email_verified_at timestamptz NULL;
+
+  ALTER TABLE users
+    ADD CONSTRAINT user_unique_email UNIQUE (email);
+END;
\ No newline at end of file
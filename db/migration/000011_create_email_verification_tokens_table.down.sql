@@ -0,0 +1,3 @@
+BEGIN;
+    DROP TABLE IF EXISTS email_verification_tokens;
+COMMIT;
\ No newline at end of file
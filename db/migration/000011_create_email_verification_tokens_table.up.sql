@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE email_verification_tokens(
+      id uuid NOT NULL PRIMARY KEY,
+      user_id uuid NOT NULL REFERENCES users(id),
+      email varchar(255) NOT NULL,
+      token_hash varchar(64) NOT NULL,
+      expires_at timestamptz NOT NULL,
+      used_at timestamptz NULL,
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      CONSTRAINT email_verification_token_unique_token_hash UNIQUE (token_hash)
+  );
+
+  CREATE INDEX email_verification_tokens_user_id_idx ON email_verification_tokens (user_id);
+END;
\ No newline at end of file
@@ -0,0 +1,4 @@
+BEGIN;
+  DELETE FROM permissions
+    WHERE name IN ('user:delete', 'user:restore');
+END;
\ No newline at end of file
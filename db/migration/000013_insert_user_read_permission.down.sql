@@ -0,0 +1,4 @@
+BEGIN;
+  DELETE FROM permissions
+    WHERE name = 'user:read';
+END;
\ No newline at end of file
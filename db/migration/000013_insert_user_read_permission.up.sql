@@ -0,0 +1,11 @@
+BEGIN;
+  INSERT INTO permissions (name, description, created_by)
+    VALUES ('user:read', 'List and read users', 'migration')
+    ON CONFLICT ON CONSTRAINT permission_unique_name DO NOTHING;
+
+  INSERT INTO role_permissions (role_id, permission_id, created_by)
+    SELECT r.id, p.id, 'migration'
+    FROM roles r CROSS JOIN permissions p
+    WHERE r.name = 'admin' AND p.name = 'user:read'
+    ON CONFLICT (role_id, permission_id) DO NOTHING;
+END;
\ No newline at end of file
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP INDEX IF EXISTS users_created_at_id_idx;
+END;
\ No newline at end of file
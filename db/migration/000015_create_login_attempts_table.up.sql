@@ -0,0 +1,10 @@
+BEGIN;
+  CREATE TABLE login_attempts(
+      key varchar(255) NOT NULL PRIMARY KEY,
+      failures int NOT NULL DEFAULT 0,
+      locked_until timestamptz NULL,
+      expires_at timestamptz NOT NULL
+  );
+
+  CREATE INDEX login_attempts_expires_at_idx ON login_attempts (expires_at);
+END;
\ No newline at end of file
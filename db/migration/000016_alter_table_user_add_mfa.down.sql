@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE users
+    DROP COLUMN IF EXISTS mfa_last_used_counter,
+    DROP COLUMN IF EXISTS mfa_enabled_at,
+    DROP COLUMN IF EXISTS mfa_secret;
+END;
\ No newline at end of file
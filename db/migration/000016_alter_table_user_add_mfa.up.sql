@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE users
+    ADD COLUMN mfa_secret text NULL,
+    ADD COLUMN mfa_enabled_at timestamptz NULL,
+    ADD COLUMN mfa_last_used_counter bigint NOT NULL DEFAULT 0;
+END;
\ No newline at end of file
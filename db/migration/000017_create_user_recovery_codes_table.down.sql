@@ -0,0 +1,3 @@
+BEGIN;
+    DROP TABLE IF EXISTS user_recovery_codes;
+END;
\ No newline at end of file
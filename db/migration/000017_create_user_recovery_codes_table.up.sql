@@ -0,0 +1,12 @@
+BEGIN;
+  CREATE TABLE user_recovery_codes(
+      id uuid NOT NULL PRIMARY KEY,
+      user_id uuid NOT NULL REFERENCES users(id),
+      code_hash varchar(64) NOT NULL,
+      used_at timestamptz NULL,
+      created_at timestamptz NOT NULL DEFAULT NOW(),
+      CONSTRAINT user_recovery_code_unique_code_hash UNIQUE (user_id, code_hash)
+  );
+
+  CREATE INDEX user_recovery_codes_user_id_idx ON user_recovery_codes (user_id);
+END;
\ No newline at end of file
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	serviceAuth "golang-rest-api/internal/service/auth"
+	"golang-rest-api/pkg/jwt"
+)
+
+type AuthHandler struct {
+	jwksProvider jwt.JWKSProvider
+	authService  serviceAuth.IAuthService
+}
+
+func NewAuthHandler(
+	jwksProvider jwt.JWKSProvider,
+	authService serviceAuth.IAuthService,
+) AuthHandler {
+	return AuthHandler{
+		jwksProvider: jwksProvider,
+		authService:  authService,
+	}
+}
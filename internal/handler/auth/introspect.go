@@ -0,0 +1,50 @@
+package auth
+
+import (
+	modelAuth "golang-rest-api/internal/model/auth"
+	pkgErr "golang-rest-api/pkg/error"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"net/http"
+)
+
+// Introspect godoc
+// @Summary      Token Introspection
+// @Description  Return state of access or refresh token as described in RFC 7662, require client credentials using HTTP basic authentication
+// @Tags         auth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        token formData string true "Token"
+// @Param        token_type_hint formData string false "Token Type Hint"
+// @Success      200  {object}  modelAuth.IntrospectResp
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/auth/introspect [post]
+func (h AuthHandler) Introspect(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	err := r.ParseForm()
+	if err != nil {
+		log.Error(ctx, "error parse form", err)
+		return pkgErr.NewCustomErrWithOriginalErr(modelAuth.ErrorIntrospectTokenRequired, err)
+	}
+
+	// token_type_hint is ignored since token type is stated in the token itself
+	req := modelAuth.IntrospectReq{
+		Token: r.PostForm.Get("token"),
+	}
+
+	if len(req.Token) == 0 {
+		return modelAuth.ErrorIntrospectTokenRequired
+	}
+
+	resp, err := h.authService.Introspect(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	httpserver.WriteJson(ctx, w, http.StatusOK, resp)
+	return nil
+}
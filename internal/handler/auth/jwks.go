@@ -0,0 +1,21 @@
+package auth
+
+import (
+	httpserver "golang-rest-api/pkg/http_server"
+	"net/http"
+)
+
+// JWKS godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys used to verify jwt issued by this service
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  jwt.JSONWebKeySet
+// @Router       /.well-known/jwks.json [get]
+func (h AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	httpserver.WriteJson(ctx, w, http.StatusOK, h.jwksProvider.JWKS())
+	return nil
+}
@@ -0,0 +1,77 @@
+package role
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelRole "golang-rest-api/internal/model/role"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+)
+
+// CreateRole godoc
+// @Summary      Create Role
+// @Description  Create role, require role:manage permission
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        request body modelRole.CreateRoleReq true "Request Body"
+// @Success      201  {object}  httpserver.HttpSuccessResponse{data=modelRole.RoleResp}
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/role [post]
+func (h RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelRole.CreateRoleReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.Actor = u.Subject
+	resp, err := h.roleService.CreateRole(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusCreated, "role created", resp)
+	return nil
+}
+
+// GetRoles godoc
+// @Summary      Get Roles
+// @Description  Get roles with their permissions, require role:manage permission
+// @Tags         role
+// @Produce      json
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=[]modelRole.RoleResp}
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/roles [get]
+func (h RoleHandler) GetRoles(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	resp, err := h.roleService.GetRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "success get roles", resp)
+	return nil
+}
@@ -0,0 +1,60 @@
+package role
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelRole "golang-rest-api/internal/model/role"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GrantPermission godoc
+// @Summary      Grant Permission
+// @Description  Grant permission to role, require role:manage permission
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "Role Name"
+// @Param        request body modelRole.GrantPermissionReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=modelRole.RoleResp}
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/role/{name}/permission [post]
+func (h RoleHandler) GrantPermission(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelRole.GrantPermissionReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.Actor = u.Subject
+	req.RoleName = chi.URLParam(r, "name")
+	resp, err := h.roleService.GrantPermission(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "permission granted", resp)
+	return nil
+}
@@ -0,0 +1,17 @@
+package role
+
+import (
+	serviceRole "golang-rest-api/internal/service/role"
+)
+
+type RoleHandler struct {
+	roleService serviceRole.IRoleService
+}
+
+func NewRoleHandler(
+	roleService serviceRole.IRoleService,
+) RoleHandler {
+	return RoleHandler{
+		roleService: roleService,
+	}
+}
@@ -0,0 +1,88 @@
+package role
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelRole "golang-rest-api/internal/model/role"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AssignUserRole godoc
+// @Summary      Assign User Role
+// @Description  Assign role to user, require role:manage permission
+// @Tags         role
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Param        request body modelRole.AssignUserRoleReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/{id}/role [post]
+func (h RoleHandler) AssignUserRole(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelRole.AssignUserRoleReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.Actor = u.Subject
+	req.UserID = chi.URLParam(r, "id")
+	err = h.roleService.AssignUserRole(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "role assigned")
+	return nil
+}
+
+// RemoveUserRole godoc
+// @Summary      Remove User Role
+// @Description  Remove role from user, require role:manage permission
+// @Tags         role
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Param        name path string true "Role Name"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/{id}/role/{name} [delete]
+func (h RoleHandler) RemoveUserRole(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	err := h.roleService.RemoveUserRole(ctx, modelRole.RemoveUserRoleReq{
+		UserID: chi.URLParam(r, "id"),
+		Role:   chi.URLParam(r, "name"),
+	})
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "role removed")
+	return nil
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+)
+
+// ChangePassword godoc
+// @Summary      Change Password
+// @Description  Change password of current user after confirming current password, other sessions of the user are revoked
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.ChangePasswordReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/password [put]
+func (h UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.ChangePasswordReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = u.Subject
+	cookie, _ := r.Cookie(modelUser.RefreshTokenCookieName)
+	if cookie != nil {
+		req.RefreshToken = cookie.Value
+	}
+
+	err = h.userService.ChangePassword(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "password changed")
+	return nil
+}
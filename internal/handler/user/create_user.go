@@ -5,21 +5,33 @@ import (
 	"golang-rest-api/internal/model"
 	modelUser "golang-rest-api/internal/model/user"
 	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
 	httpserver "golang-rest-api/pkg/http_server"
 	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
 	"net/http"
 )
 
 func (h UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
-	req := modelUser.CreateUserReq{}
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
 
-	err := json.NewDecoder(r.Body).Decode(&req)
+	req := modelUser.CreateUserReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.Error(ctx, "error decode json", err)
 		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
 	}
 
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.Actor = u.Subject
 	resp, err := h.userService.CreateUser(ctx, req)
 	if err != nil {
 		return err
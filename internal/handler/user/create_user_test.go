@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	modelUser "golang-rest-api/internal/model/user"
+	repoUser "golang-rest-api/internal/repository/user"
+	serviceUser "golang-rest-api/internal/service/user"
+	"golang-rest-api/pkg/crypter"
+	"golang-rest-api/pkg/database"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/jwt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type stubJWTParser struct {
+	claims jwt.JWTClaims
+}
+
+func (p stubJWTParser) ParseAndValidate(ctx context.Context, tokenString string) (jwt.JWTClaims, error) {
+	return p.claims, nil
+}
+
+func (p stubJWTParser) ParseAndValidateWithTokenType(ctx context.Context, tokenString string, tokenType jwt.JWTTokenType) (jwt.JWTClaims, error) {
+	return p.claims, nil
+}
+
+// stubDB only begin transaction which commit and rollback without error
+type stubDB struct {
+	database.IPostgres
+}
+
+func (db stubDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return stubTx{}, nil
+}
+
+type stubTx struct {
+	pgx.Tx
+}
+
+func (tx stubTx) Commit(ctx context.Context) error {
+	return nil
+}
+
+func (tx stubTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+// stubUserRepo record user inserted by CreateUserTx
+type stubUserRepo struct {
+	repoUser.IUserRepo
+	inserted []modelUser.InsertUser
+}
+
+func (r *stubUserRepo) CreateUserTx(ctx context.Context, tx pgx.Tx, args modelUser.InsertUser) error {
+	r.inserted = append(r.inserted, args)
+	return nil
+}
+
+func TestCreateUserActor(t *testing.T) {
+	repo := &stubUserRepo{}
+	userService := serviceUser.NewUserService(
+		serviceUser.WithUserRepo(repo),
+		serviceUser.WithTxHandler(stubDB{}),
+		serviceUser.WithCrypter(crypter.New(crypter.WithAlgorithm(crypter.AlgorithmBcrypt), crypter.WithBcryptCost(crypter.LegacyBcryptCost))),
+	)
+	handler := httpmiddleware.JWTAuthUser(
+		stubJWTParser{claims: jwt.JWTClaims{Subject: "admin-id"}},
+		"",
+	)(httpserver.HandlerWithError(NewUserHandler(userService).CreateUser))
+
+	body := `{"name":"New User","username":"new.user","password":"Correct-Horse-42"}`
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/user", strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d, body %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	if len(repo.inserted) != 1 {
+		t.Fatalf("CreateUserTx called %d times, want 1", len(repo.inserted))
+	}
+
+	if repo.inserted[0].Actor != "admin-id" {
+		t.Errorf("actor = %q, want %q", repo.inserted[0].Actor, "admin-id")
+	}
+}
@@ -0,0 +1,121 @@
+package user
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DeleteUser godoc
+// @Summary      Delete User
+// @Description  Soft delete user and revoke every session of the user, require user:delete permission
+// @Tags         user
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/{id} [delete]
+func (h UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = h.userService.DeleteUser(ctx, modelUser.DeleteUserReq{
+		ID:    chi.URLParam(r, "id"),
+		Actor: u.Subject,
+	})
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "user deleted")
+	return nil
+}
+
+// RestoreUser godoc
+// @Summary      Restore User
+// @Description  Restore soft deleted user, require user:restore permission
+// @Tags         user
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/{id}/restore [post]
+func (h UserHandler) RestoreUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = h.userService.RestoreUser(ctx, modelUser.RestoreUserReq{
+		ID:    chi.URLParam(r, "id"),
+		Actor: u.Subject,
+	})
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "user restored")
+	return nil
+}
+
+// DeleteAccount godoc
+// @Summary      Delete Account
+// @Description  Soft delete account of current user after confirming password, every session of the user is revoked
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.DeleteAccountReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/profile [delete]
+func (h UserHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.DeleteAccountReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = u.Subject
+	err = h.userService.DeleteAccount(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	clearTokenCookies(w)
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "account deleted")
+	return nil
+}
@@ -0,0 +1,109 @@
+package user
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+)
+
+// SendEmailVerification godoc
+// @Summary      Send Email Verification
+// @Description  Mail new verification link to email of current user
+// @Tags         user
+// @Produce      json
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/email/verification [post]
+func (h UserHandler) SendEmailVerification(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = h.userService.SendEmailVerification(ctx, u.Subject)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "verification mail sent")
+	return nil
+}
+
+// ResendEmailVerification godoc
+// @Summary      Resend Email Verification
+// @Description  Mail new verification link to user who can not log in because email is not verified, login is username or email, response is the same whether the user exists or not
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.ResendEmailVerificationReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/email/verification/resend [post]
+func (h UserHandler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req := modelUser.ResendEmailVerificationReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.ClientIP = clientIP(r)
+	err = h.userService.ResendEmailVerification(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "verification mail sent if the account has unverified email")
+	return nil
+}
+
+// VerifyEmail godoc
+// @Summary      Verify Email
+// @Description  Mark email of user as verified using email verification token
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.VerifyEmailReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/email/verify [post]
+func (h UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req := modelUser.VerifyEmailReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	err = h.userService.VerifyEmail(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "email verified")
+	return nil
+}
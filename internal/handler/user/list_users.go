@@ -0,0 +1,139 @@
+package user
+
+import (
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListUsers godoc
+// @Summary      List Users
+// @Description  List users with pagination, filtering and sorting, require user:read permission.
+// @Description  Cursor pagination is used when cursor param is sent (empty to start), meta then contain next_cursor instead of offset and total
+// @Tags         user
+// @Produce      json
+// @Param        limit           query int    false "Limit, default 20, max 100"
+// @Param        offset          query int    false "Offset"
+// @Param        cursor          query string false "Cursor of next page"
+// @Param        username        query string false "Username prefix"
+// @Param        name            query string false "Name prefix, case insensitive"
+// @Param        phone           query string false "Phone prefix"
+// @Param        created_from    query string false "Created at or after, RFC 3339"
+// @Param        created_to      query string false "Created before, RFC 3339"
+// @Param        include_deleted query bool   false "Include deleted users"
+// @Param        sort_by         query string false "created_at (default), username or name"
+// @Param        sort_order      query string false "asc or desc (default)"
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=[]modelUser.UserResp,meta=httpserver.PaginationMetaInfo}
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/users [get]
+func (h UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req, err := parseListUsersReq(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	err = validator.ValidateQuery(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.userService.ListUsers(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if req.UseCursor {
+		httpserver.WriteJsonMsgWithDataAndMeta(ctx, w, http.StatusOK, "success list users", resp.Users, httpserver.CursorPaginationMetaInfo{
+			Limit:      req.Limit,
+			NextCursor: resp.NextCursor,
+		})
+		return nil
+	}
+
+	httpserver.WriteJsonMsgWithDataAndMeta(ctx, w, http.StatusOK, "success list users", resp.Users, httpserver.PaginationMetaInfo{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Total:  resp.Total,
+	})
+	return nil
+}
+
+func parseListUsersReq(query url.Values) (modelUser.ListUsersReq, error) {
+	req := modelUser.ListUsersReq{
+		Limit:     modelUser.ListUsersDefaultLimit,
+		Username:  query.Get("username"),
+		Name:      query.Get("name"),
+		Phone:     query.Get("phone"),
+		SortBy:    "created_at",
+		SortOrder: "desc",
+		UseCursor: query.Has("cursor"),
+		Cursor:    query.Get("cursor"),
+	}
+
+	var err error
+	if v := query.Get("limit"); len(v) > 0 {
+		req.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return req, invalidQueryParam("limit", "number", "must be a number")
+		}
+	}
+
+	if v := query.Get("offset"); len(v) > 0 {
+		req.Offset, err = strconv.Atoi(v)
+		if err != nil {
+			return req, invalidQueryParam("offset", "number", "must be a number")
+		}
+	}
+
+	if v := query.Get("created_from"); len(v) > 0 {
+		createdFrom, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return req, invalidQueryParam("created_from", "datetime", "must be RFC 3339 time")
+		}
+		req.CreatedFrom = &createdFrom
+	}
+
+	if v := query.Get("created_to"); len(v) > 0 {
+		createdTo, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return req, invalidQueryParam("created_to", "datetime", "must be RFC 3339 time")
+		}
+		req.CreatedTo = &createdTo
+	}
+
+	if v := query.Get("include_deleted"); len(v) > 0 {
+		req.IncludeDeleted, err = strconv.ParseBool(v)
+		if err != nil {
+			return req, invalidQueryParam("include_deleted", "boolean", "must be a boolean")
+		}
+	}
+
+	if v := query.Get("sort_by"); len(v) > 0 {
+		req.SortBy = v
+	}
+
+	if v := query.Get("sort_order"); len(v) > 0 {
+		req.SortOrder = v
+	}
+
+	if req.UseCursor && req.SortBy != "created_at" {
+		return req, invalidQueryParam("sort_by", "oneof", "must be created_at when cursor is used")
+	}
+
+	return req, nil
+}
+
+func invalidQueryParam(field string, rule string, message string) error {
+	return pkgErr.NewCustomErrWithDetails(validator.ErrorQueryParamNotValid, []validator.FieldError{
+		{Field: field, Rule: rule, Message: message},
+	})
+}
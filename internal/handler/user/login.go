@@ -2,26 +2,27 @@ package user
 
 import (
 	"encoding/json"
-	"fmt"
 	"golang-rest-api/internal/model"
 	modelUser "golang-rest-api/internal/model/user"
 	pkgErr "golang-rest-api/pkg/error"
 	httpserver "golang-rest-api/pkg/http_server"
 	"golang-rest-api/pkg/log"
 	"golang-rest-api/pkg/validator"
+	"net"
 	"net/http"
 )
 
 // Login godoc
 // @Summary      Login
-// @Description  Login
+// @Description  Login, when user has enabled MFA mfa token is returned instead which must be exchanged at /api/v1/user/login/mfa
 // @Tags         user
 // @Accept       json
 // @Produce      json
 // @Param        request body modelUser.UserLoginReq true "Request Body"
-// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=modelUser.MFAChallengeResp}
 // @Failure      400  {object}  httpserver.HttpErrorResponse
-// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
 // @Failure      500  {object}  httpserver.HttpErrorResponse
 // @Router       /api/v1/user/login [post]
 func (h UserHandler) Login(w http.ResponseWriter, r *http.Request) error {
@@ -34,16 +35,44 @@ func (h UserHandler) Login(w http.ResponseWriter, r *http.Request) error {
 		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
 	}
 
-	err = validator.Validate.StructCtx(ctx, req)
+	err = validator.ValidateStruct(ctx, req)
 	if err != nil {
-		return pkgErr.NewCustomError(fmt.Sprintf("payload not valid: %s", err.Error()), "PAYLOAD_NOT_VALID", http.StatusBadRequest)
+		return err
 	}
 
+	req.ClientIP = clientIP(r)
 	jwtToken, err := h.userService.UserLogin(ctx, req)
 	if err != nil {
 		return err
 	}
 
+	if jwtToken.MFARequired {
+		httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "mfa required", modelUser.MFAChallengeResp{
+			MFARequired: true,
+			MFAToken:    jwtToken.MFAToken,
+			ExpiresAt:   jwtToken.MFATokenExpiresAt,
+		})
+		return nil
+	}
+
+	setTokenCookies(w, jwtToken)
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "login success")
+	return nil
+}
+
+// clientIP return ip of the connection, proxy header is not trusted
+// because it can be set by the client
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func setTokenCookies(w http.ResponseWriter, jwtToken modelUser.UserLoginResp) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     modelUser.AccessTokenCookieName,
 		Value:    jwtToken.AccessToken,
@@ -57,7 +86,15 @@ func (h UserHandler) Login(w http.ResponseWriter, r *http.Request) error {
 		Expires:  jwtToken.RefreshTokenExpiresAt,
 		HttpOnly: true,
 	})
+}
 
-	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "login success")
-	return nil
+func clearTokenCookies(w http.ResponseWriter) {
+	for _, cookieName := range []string{modelUser.AccessTokenCookieName, modelUser.RefreshTokenCookieName} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     cookieName,
+			Value:    "",
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
+	}
 }
@@ -0,0 +1,45 @@
+package user
+
+import (
+	modelUser "golang-rest-api/internal/model/user"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"net/http"
+)
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Revoke current access token and refresh token session, then clear token cookies
+// @Tags         user
+// @Produce      json
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/logout [post]
+func (h UserHandler) Logout(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.UserLogoutReq{
+		AccessTokenID:        u.ID,
+		AccessTokenExpiresAt: u.ExpireAt.Time,
+	}
+
+	cookie, _ := r.Cookie(modelUser.RefreshTokenCookieName)
+	if cookie != nil {
+		req.RefreshToken = cookie.Value
+	}
+
+	err = h.userService.UserLogout(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	clearTokenCookies(w)
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "logout success")
+	return nil
+}
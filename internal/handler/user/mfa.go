@@ -0,0 +1,189 @@
+package user
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpmiddleware "golang-rest-api/pkg/http_middleware"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+)
+
+// EnrollMFA godoc
+// @Summary      Enroll MFA
+// @Description  Generate TOTP secret for current user after confirming password, MFA is enabled after the secret is confirmed
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.EnrollMFAReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=modelUser.EnrollMFAResp}
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/mfa/enroll [post]
+func (h UserHandler) EnrollMFA(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.EnrollMFAReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = u.Subject
+	res, err := h.userService.EnrollMFA(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "mfa enrolled", res)
+	return nil
+}
+
+// ConfirmMFA godoc
+// @Summary      Confirm MFA
+// @Description  Enable MFA using password and code of enrolled secret, recovery codes are returned only once,
+// @Description  every other session of current user is revoked
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.ConfirmMFAReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=modelUser.ConfirmMFAResp}
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/mfa/confirm [post]
+func (h UserHandler) ConfirmMFA(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.ConfirmMFAReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = u.Subject
+	cookie, _ := r.Cookie(modelUser.RefreshTokenCookieName)
+	if cookie != nil {
+		req.RefreshToken = cookie.Value
+	}
+
+	res, err := h.userService.ConfirmMFA(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "mfa enabled", res)
+	return nil
+}
+
+// DisableMFA godoc
+// @Summary      Disable MFA
+// @Description  Disable MFA of current user, password and TOTP or recovery code are required to confirm,
+// @Description  every other session of current user is revoked
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.DisableMFAReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/mfa/disable [post]
+func (h UserHandler) DisableMFA(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.DisableMFAReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = u.Subject
+	cookie, _ := r.Cookie(modelUser.RefreshTokenCookieName)
+	if cookie != nil {
+		req.RefreshToken = cookie.Value
+	}
+
+	err = h.userService.DisableMFA(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "mfa disabled")
+	return nil
+}
+
+// LoginMFA godoc
+// @Summary      Login MFA
+// @Description  Exchange mfa token returned by login with access token using TOTP or recovery code
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.LoginMFAReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/login/mfa [post]
+func (h UserHandler) LoginMFA(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req := modelUser.LoginMFAReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	jwtToken, err := h.userService.LoginMFA(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	setTokenCookies(w, jwtToken)
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "login success")
+	return nil
+}
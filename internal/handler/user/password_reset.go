@@ -0,0 +1,82 @@
+package user
+
+import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
+	"net/http"
+)
+
+// ForgotPassword godoc
+// @Summary      Forgot Password
+// @Description  Mail password reset link to user, response is the same whether the username exists or not
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.ForgotPasswordReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      429  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/password/forgot [post]
+func (h UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req := modelUser.ForgotPasswordReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.ClientIP = clientIP(r)
+	err = h.userService.ForgotPassword(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "password reset link sent if the account exists")
+	return nil
+}
+
+// ResetPassword godoc
+// @Summary      Reset Password
+// @Description  Set new password using password reset token, every session of the user is revoked
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.ResetPasswordReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/password/reset [post]
+func (h UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req := modelUser.ResetPasswordReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	err = h.userService.ResetPassword(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "password reset")
+	return nil
+}
@@ -1,9 +1,17 @@
 package user
 
 import (
+	"encoding/json"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
 	httpmiddleware "golang-rest-api/pkg/http_middleware"
 	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/validator"
 	"net/http"
+
+	"github.com/go-chi/chi/v5"
 )
 
 func (h UserHandler) UserProfile(w http.ResponseWriter, r *http.Request) error {
@@ -21,3 +29,69 @@ func (h UserHandler) UserProfile(w http.ResponseWriter, r *http.Request) error {
 	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "success get progile", resp)
 	return nil
 }
+
+// UserDetail godoc
+// @Summary      Get User
+// @Description  Get user by id including deleted user with audit fields, require user:read permission
+// @Tags         user
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=modelUser.UserDetailResp}
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      403  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/{id} [get]
+func (h UserHandler) UserDetail(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	resp, err := h.userService.UserDetail(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "success get user", resp)
+	return nil
+}
+
+// UpdateProfile godoc
+// @Summary      Update Profile
+// @Description  Partially update profile of current user, only field sent in request body is updated
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.UpdateProfileReq true "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse{data=modelUser.UserProfileResp}
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      404  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/profile [patch]
+func (h UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	u, err := httpmiddleware.GetUserClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := modelUser.UpdateProfileReq{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Error(ctx, "error decode json", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+	}
+
+	err = validator.ValidateStruct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	req.ID = u.Subject
+	req.Actor = u.Subject
+	resp, err := h.userService.UpdateUserProfile(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	httpserver.WriteJsonMsgWithData(ctx, w, http.StatusOK, "success update profile", resp)
+	return nil
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"golang-rest-api/internal/model"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"io"
+	"net/http"
+)
+
+// RefreshToken godoc
+// @Summary      Refresh Token
+// @Description  Exchange refresh token from cookie or request body with new access and refresh token
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body modelUser.RefreshTokenReq false "Request Body"
+// @Success      200  {object}  httpserver.HttpSuccessResponse
+// @Failure      400  {object}  httpserver.HttpErrorResponse
+// @Failure      401  {object}  httpserver.HttpErrorResponse
+// @Failure      500  {object}  httpserver.HttpErrorResponse
+// @Router       /api/v1/user/token/refresh [post]
+func (h UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	req := modelUser.RefreshTokenReq{}
+
+	cookie, _ := r.Cookie(modelUser.RefreshTokenCookieName)
+	if cookie != nil {
+		req.RefreshToken = cookie.Value
+	}
+
+	if len(req.RefreshToken) == 0 {
+		// empty body is not an error, refresh token is then reported as missing
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Error(ctx, "error decode json", err)
+			return pkgErr.NewCustomErrWithOriginalErr(model.ErrorInvalidJson, err)
+		}
+	}
+
+	if len(req.RefreshToken) == 0 {
+		return modelUser.ErrorRefreshTokenRequired
+	}
+
+	jwtToken, err := h.userService.UserRefreshToken(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	setTokenCookies(w, jwtToken)
+
+	httpserver.WriteJsonMsgOnly(ctx, w, http.StatusOK, "refresh token success")
+	return nil
+}
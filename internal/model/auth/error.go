@@ -0,0 +1,10 @@
+package auth
+
+import (
+	pkgErr "golang-rest-api/pkg/error"
+	"net/http"
+)
+
+var (
+	ErrorIntrospectTokenRequired = pkgErr.NewCustomError("token is required", "INVALID_REQUEST", http.StatusBadRequest)
+)
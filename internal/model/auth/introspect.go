@@ -0,0 +1,21 @@
+package auth
+
+type IntrospectReq struct {
+	Token string
+}
+
+// IntrospectResp token introspection response as defined in RFC 7662,
+// only active field is filled when token is not active
+type IntrospectResp struct {
+	Active    bool     `json:"active"`
+	Scope     string   `json:"scope,omitempty"`
+	TokenType string   `json:"token_type,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  []string `json:"aud,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	JTI       string   `json:"jti,omitempty"`
+	// AuthMethods authentication methods used to obtain the token, e.g. pwd and otp
+	AuthMethods []string `json:"amr,omitempty"`
+}
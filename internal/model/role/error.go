@@ -0,0 +1,12 @@
+package role
+
+import (
+	pkgErr "golang-rest-api/pkg/error"
+	"net/http"
+)
+
+var (
+	ErrorDuplicateRole      = pkgErr.NewCustomError("error duplicate role", "ROLE_ERROR_DUPLICATE_NAME", http.StatusBadRequest)
+	ErrorRoleNotFound       = pkgErr.NewCustomError("error role not found", "ROLE_NOT_FOUND", http.StatusNotFound)
+	ErrorPermissionNotFound = pkgErr.NewCustomError("error permission not found", "PERMISSION_NOT_FOUND", http.StatusNotFound)
+)
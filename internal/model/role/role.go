@@ -0,0 +1,81 @@
+package role
+
+const (
+	RoleAdmin = "admin"
+)
+
+const (
+	PermissionUserCreate  = "user:create"
+	PermissionRoleManage  = "role:manage"
+	PermissionUserDelete  = "user:delete"
+	PermissionUserRestore = "user:restore"
+	PermissionUserRead    = "user:read"
+)
+
+type InsertRole struct {
+	ID          string
+	Name        string
+	Description string
+	Actor       string
+}
+
+type Role struct {
+	ID          string   `db:"id"`
+	Name        string   `db:"name"`
+	Description string   `db:"description"`
+	Permissions []string `db:"permissions"`
+}
+
+type Permission struct {
+	ID          string `db:"id"`
+	Name        string `db:"name"`
+	Description string `db:"description"`
+}
+
+type InsertRolePermission struct {
+	RoleID       string
+	PermissionID string
+	Actor        string
+}
+
+type InsertUserRole struct {
+	UserID string
+	RoleID string
+	Actor  string
+}
+
+// UserAuthorization roles of user and permissions granted by those roles
+type UserAuthorization struct {
+	Roles       []string `db:"roles"`
+	Permissions []string `db:"permissions"`
+}
+
+type CreateRoleReq struct {
+	Actor       string `json:"-"`
+	Name        string `json:"name" validate:"required,max=50"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type RoleResp struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type GrantPermissionReq struct {
+	Actor      string `json:"-"`
+	RoleName   string `json:"-"`
+	Permission string `json:"permission" validate:"required"`
+}
+
+type AssignUserRoleReq struct {
+	Actor  string `json:"-"`
+	UserID string `json:"-"`
+	Role   string `json:"role" validate:"required"`
+}
+
+type RemoveUserRoleReq struct {
+	UserID string
+	Role   string
+}
@@ -0,0 +1,28 @@
+package user
+
+import "time"
+
+type InsertEmailVerificationToken struct {
+	ID        string
+	UserID    string
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// EmailVerificationToken user and email which the token was issued for
+type EmailVerificationToken struct {
+	UserID string `db:"user_id"`
+	Email  string `db:"email"`
+}
+
+type VerifyEmailReq struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ResendEmailVerificationReq request verification mail without login, e.g. after the previous link expired,
+// Login is either username or email of the user
+type ResendEmailVerificationReq struct {
+	ClientIP string `json:"-"`
+	Login    string `json:"login" validate:"required"`
+}
@@ -6,7 +6,28 @@ import (
 )
 
 var (
-	ErrorDuplicateUsername       = pkgErr.NewCustomError("error duplicate username", "USER_ERROR_DUPLICATE_USERNAME", http.StatusBadRequest)
-	ErrorUserNotFound            = pkgErr.NewCustomError("error user not found", "USER_NOT_FOUND", http.StatusNotFound)
-	ErrorLoginErrorWrongPassword = pkgErr.NewCustomError("error password", "LOGIN_ERROR_WRONG_PASSWORD", http.StatusBadRequest)
+	ErrorDuplicateUsername             = pkgErr.NewCustomError("error duplicate username", "USER_ERROR_DUPLICATE_USERNAME", http.StatusBadRequest)
+	ErrorDuplicateEmail                = pkgErr.NewCustomError("error duplicate email", "USER_ERROR_DUPLICATE_EMAIL", http.StatusBadRequest)
+	ErrorUserNotFound                  = pkgErr.NewCustomError("error user not found", "USER_NOT_FOUND", http.StatusNotFound)
+	ErrorDeletedUserNotFound           = pkgErr.NewCustomError("error deleted user not found", "DELETED_USER_NOT_FOUND", http.StatusNotFound)
+	ErrorInvalidCredentials            = pkgErr.NewCustomError("invalid username or password", "INVALID_CREDENTIALS", http.StatusUnauthorized)
+	ErrorAccountLocked                 = pkgErr.NewCustomError("account temporarily locked due to too many failed login attempts", "ACCOUNT_LOCKED", http.StatusTooManyRequests)
+	ErrorTooManyRequests               = pkgErr.NewCustomError("too many requests, try again later", "TOO_MANY_REQUESTS", http.StatusTooManyRequests)
+	ErrorCurrentPasswordNotMatch       = pkgErr.NewCustomError("current password not match", "CURRENT_PASSWORD_NOT_MATCH", http.StatusBadRequest)
+	ErrorInvalidAudience               = pkgErr.NewCustomError("audience not allowed", "INVALID_AUDIENCE", http.StatusBadRequest)
+	ErrorRefreshTokenRequired          = pkgErr.NewCustomError("refresh token is required", "REFRESH_TOKEN_REQUIRED", http.StatusUnauthorized)
+	ErrorRefreshTokenReused            = pkgErr.NewCustomError("refresh token already used", "REFRESH_TOKEN_REUSED", http.StatusUnauthorized)
+	ErrorSessionNotFound               = pkgErr.NewCustomError("session not found", "SESSION_NOT_FOUND", http.StatusUnauthorized)
+	ErrorSessionRevoked                = pkgErr.NewCustomError("session revoked", "SESSION_REVOKED", http.StatusUnauthorized)
+	ErrorEmailNotVerified              = pkgErr.NewCustomError("email not verified", "EMAIL_NOT_VERIFIED", http.StatusForbidden)
+	ErrorEmailNotSet                   = pkgErr.NewCustomError("user has no email", "EMAIL_NOT_SET", http.StatusBadRequest)
+	ErrorEmailAlreadyVerified          = pkgErr.NewCustomError("email already verified", "EMAIL_ALREADY_VERIFIED", http.StatusBadRequest)
+	ErrorEmailVerificationTokenInvalid = pkgErr.NewCustomError("email verification token invalid or expired", "EMAIL_VERIFICATION_TOKEN_INVALID", http.StatusBadRequest)
+	ErrorPasswordResetTokenInvalid     = pkgErr.NewCustomError("password reset token invalid or expired", "PASSWORD_RESET_TOKEN_INVALID", http.StatusBadRequest)
+	ErrorMFAUnavailable                = pkgErr.NewCustomError("multi-factor authentication is not configured", "MFA_UNAVAILABLE", http.StatusNotImplemented)
+	ErrorMFAAlreadyEnabled             = pkgErr.NewCustomError("multi-factor authentication already enabled", "MFA_ALREADY_ENABLED", http.StatusBadRequest)
+	ErrorMFANotEnrolled                = pkgErr.NewCustomError("multi-factor authentication not enrolled", "MFA_NOT_ENROLLED", http.StatusBadRequest)
+	ErrorMFANotEnabled                 = pkgErr.NewCustomError("multi-factor authentication not enabled", "MFA_NOT_ENABLED", http.StatusBadRequest)
+	ErrorMFACodeInvalid                = pkgErr.NewCustomError("invalid multi-factor authentication code", "INVALID_MFA_CODE", http.StatusUnauthorized)
+	ErrorMFATokenInvalid               = pkgErr.NewCustomError("mfa token invalid or expired", "INVALID_MFA_TOKEN", http.StatusUnauthorized)
 )
@@ -0,0 +1,56 @@
+package user
+
+import "time"
+
+const (
+	ListUsersDefaultLimit = 20
+)
+
+// ListUsersFilter string filter match by prefix, nil time range is not applied
+type ListUsersFilter struct {
+	Limit          int
+	Offset         int
+	Username       string
+	Name           string
+	Phone          string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	IncludeDeleted bool
+	SortBy         string
+	SortOrder      string
+}
+
+// ListUsersReq Cursor is used instead of Offset when UseCursor is set,
+// empty Cursor start from the first page
+type ListUsersReq struct {
+	UseCursor      bool       `json:"-"`
+	Cursor         string     `json:"cursor"`
+	Limit          int        `json:"limit" validate:"min=1,max=100"`
+	Offset         int        `json:"offset" validate:"min=0"`
+	Username       string     `json:"username"`
+	Name           string     `json:"name"`
+	Phone          string     `json:"phone"`
+	CreatedFrom    *time.Time `json:"created_from"`
+	CreatedTo      *time.Time `json:"created_to"`
+	IncludeDeleted bool       `json:"include_deleted"`
+	SortBy         string     `json:"sort_by" validate:"oneof=created_at username name"`
+	SortOrder      string     `json:"sort_order" validate:"oneof=asc desc"`
+}
+
+type UserResp struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Username      string     `json:"username"`
+	Phone         string     `json:"phone"`
+	Email         *string    `json:"email"`
+	EmailVerified bool       `json:"email_verified"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeletedAt     *time.Time `json:"deleted_at"`
+}
+
+// ListUsersResp Total is only counted on offset pagination, NextCursor only on cursor pagination
+type ListUsersResp struct {
+	Users      []UserResp
+	Total      int
+	NextCursor string
+}
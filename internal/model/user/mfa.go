@@ -0,0 +1,66 @@
+package user
+
+import "time"
+
+// RecoveryCodeCount number of recovery codes issued when MFA is enabled
+const RecoveryCodeCount = 10
+
+// UserMFA secret is encrypted, it is set on enrollment and only used for login after MFA is enabled
+type UserMFA struct {
+	Secret          *string    `db:"mfa_secret"`
+	EnabledAt       *time.Time `db:"mfa_enabled_at"`
+	LastUsedCounter int64      `db:"mfa_last_used_counter"`
+}
+
+type InsertRecoveryCode struct {
+	ID       string
+	UserID   string
+	CodeHash string
+}
+
+type EnrollMFAReq struct {
+	UserID   string `json:"-"`
+	Password string `json:"password" validate:"required"`
+}
+
+type EnrollMFAResp struct {
+	Secret string `json:"secret"`
+	// OTPAuthURI uri to be shown as QR code and scanned by authenticator app
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type ConfirmMFAReq struct {
+	UserID string `json:"-"`
+	// RefreshToken identify current session which is kept after MFA is enabled
+	RefreshToken string `json:"-"`
+	Password     string `json:"password" validate:"required"`
+	Code         string `json:"code" validate:"required"`
+}
+
+type ConfirmMFAResp struct {
+	// RecoveryCodes each code can be used once in place of TOTP code, only shown once
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableMFAReq code can be TOTP code or recovery code
+type DisableMFAReq struct {
+	UserID string `json:"-"`
+	// RefreshToken identify current session which is kept after MFA is disabled
+	RefreshToken string `json:"-"`
+	Password     string `json:"password" validate:"required"`
+	Code         string `json:"code" validate:"required"`
+}
+
+// LoginMFAReq exchange mfa token returned by login with access token,
+// code can be TOTP code or recovery code
+type LoginMFAReq struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// MFAChallengeResp returned by login instead of token when user has enabled MFA
+type MFAChallengeResp struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
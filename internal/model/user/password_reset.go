@@ -0,0 +1,20 @@
+package user
+
+import "time"
+
+type InsertPasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type ForgotPasswordReq struct {
+	ClientIP string `json:"-"`
+	Username string `json:"username" validate:"required"`
+}
+
+type ResetPasswordReq struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max_bytes=72,password_strength"`
+}
@@ -0,0 +1,20 @@
+package user
+
+import "time"
+
+type InsertSession struct {
+	ID        string
+	FamilyID  string
+	UserID    string
+	ExpiresAt time.Time
+}
+
+type Session struct {
+	ID         string     `db:"id"`
+	FamilyID   string     `db:"family_id"`
+	UserID     string     `db:"user_id"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	RotatedAt  *time.Time `db:"rotated_at"`
+	ReplacedBy *string    `db:"replaced_by"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
@@ -12,49 +12,146 @@ type InsertUser struct {
 	Name     string
 	Username string
 	Phone    string
+	Email    *string
+	Password string
+	Actor    string
+}
+
+// UpdateUser only non nil field is updated, changing email reset its verification
+type UpdateUser struct {
+	ID    string
+	Name  *string
+	Phone *string
+	Email *string
+	Actor string
+}
+
+type UpdateUserPassword struct {
+	ID       string
 	Password string
 	Actor    string
 }
 
 type User struct {
-	ID       string `db:"id"`
-	Name     string `db:"name"`
-	Username string `db:"username"`
-	Phone    string `db:"phone"`
-	Password string `db:"password"`
+	ID              string     `db:"id"`
+	Name            string     `db:"name"`
+	Username        string     `db:"username"`
+	Phone           string     `db:"phone"`
+	Email           *string    `db:"email"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	Password        string     `db:"password"`
+	MFAEnabledAt    *time.Time `db:"mfa_enabled_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	DeletedAt       *time.Time `db:"deleted_at"`
 }
 
 type CreateUserReq struct {
 	ID       string `json:"-"`
 	Actor    string `json:"-"`
-	Name     string `json:"name"`
-	Username string `json:"username"`
-	Phone    string `json:"phone"`
-	Password string `json:"password"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Username string `json:"username" validate:"required,username"`
+	Phone    string `json:"phone" validate:"phone"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
+	// Password bcrypt only use the first 72 bytes, multi-byte character count as more than one
+	Password string `json:"password" validate:"required,max_bytes=72,password_strength"`
 }
 
 type CreateUserResp struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Username string `json:"username"`
-	Phone    string `json:"phone"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Username string  `json:"username"`
+	Phone    string  `json:"phone"`
+	Email    *string `json:"email"`
 }
 
 type UserLoginReq struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	// Audience client which the token is issued for, e.g. web or mobile app, default audience is used when empty
+	Audience string `json:"audience"`
+	ClientIP string `json:"-"`
 }
 
+// UserLoginResp when MFARequired only MFAToken is set,
+// it must be exchanged with access token by verifying MFA code
 type UserLoginResp struct {
 	AccessToken           string
 	ExpiresAt             time.Time
 	RefreshToken          string
 	RefreshTokenExpiresAt time.Time
+	MFARequired           bool
+	MFAToken              string
+	MFATokenExpiresAt     time.Time
+}
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserLogoutReq struct {
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
 }
 
 type UserProfileResp struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Username string `json:"username"`
-	Phone    string `json:"phone"`
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Username      string  `json:"username"`
+	Phone         string  `json:"phone"`
+	Email         *string `json:"email"`
+	EmailVerified bool    `json:"email_verified"`
+	MFAEnabled    bool    `json:"mfa_enabled"`
+}
+
+// UpdateProfileReq only non nil field is updated
+type UpdateProfileReq struct {
+	ID    string  `json:"-"`
+	Actor string  `json:"-"`
+	Name  *string `json:"name" validate:"omitnil,min=1,max=255"`
+	Phone *string `json:"phone" validate:"omitnil,phone"`
+	Email *string `json:"email" validate:"omitnil,email,max=255"`
+}
+
+type ChangePasswordReq struct {
+	UserID string `json:"-"`
+	// RefreshToken identify current session which is kept after password changed
+	RefreshToken    string `json:"-"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,max_bytes=72,password_strength,nefield=CurrentPassword"`
+}
+
+type DeleteUserReq struct {
+	ID    string `json:"-"`
+	Actor string `json:"-"`
+}
+
+// DeleteAccountReq delete account of current user, password is required to confirm
+type DeleteAccountReq struct {
+	UserID   string `json:"-"`
+	Password string `json:"password" validate:"required"`
+}
+
+type RestoreUserReq struct {
+	ID    string `json:"-"`
+	Actor string `json:"-"`
+}
+
+// UserDetail user which may be deleted with its audit fields
+type UserDetail struct {
+	User
+	CreatedBy *string   `db:"created_by"`
+	UpdatedAt time.Time `db:"updated_at"`
+	UpdatedBy *string   `db:"updated_by"`
+	DeletedBy *string   `db:"deleted_by"`
+}
+
+type UserDetailResp struct {
+	UserProfileResp
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy *string    `json:"created_by"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	UpdatedBy *string    `json:"updated_by"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	DeletedBy *string    `json:"deleted_by"`
 }
@@ -0,0 +1,231 @@
+package role
+
+import (
+	"context"
+	"golang-rest-api/internal/model"
+	roleModel "golang-rest-api/internal/model/role"
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type IRoleRepo interface {
+	CreateRoleTx(ctx context.Context, tx pgx.Tx, args roleModel.InsertRole) error
+	GetRoles(ctx context.Context) ([]roleModel.Role, error)
+	GetRoleByName(ctx context.Context, name string) (roleModel.Role, error)
+	GetPermissionByName(ctx context.Context, name string) (roleModel.Permission, error)
+	GrantPermissionTx(ctx context.Context, tx pgx.Tx, args roleModel.InsertRolePermission) error
+	AssignUserRoleTx(ctx context.Context, tx pgx.Tx, args roleModel.InsertUserRole) error
+	RemoveUserRoleTx(ctx context.Context, tx pgx.Tx, userID string, roleID string) error
+	GetUserAuthorization(ctx context.Context, userID string) (roleModel.UserAuthorization, error)
+}
+
+type RoleRepo struct {
+	db database.IPostgres
+}
+
+func NewRoleRepo(db database.IPostgres) *RoleRepo {
+	return &RoleRepo{
+		db: db,
+	}
+}
+
+func (r RoleRepo) CreateRoleTx(ctx context.Context, tx pgx.Tx, args roleModel.InsertRole) error {
+	query := `INSERT INTO roles (id, name, description, created_by)
+		VALUES ($1, $2, $3, $4);`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		args.ID,
+		args.Name,
+		args.Description,
+		args.Actor,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error create role", err)
+
+		if errPg, ok := err.(*pgconn.PgError); ok {
+			switch errPg.Code {
+			case "23505":
+				switch errPg.ConstraintName {
+				case "role_unique_name":
+					return roleModel.ErrorDuplicateRole
+				}
+			}
+		}
+
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+func (r RoleRepo) GetRoles(ctx context.Context) ([]roleModel.Role, error) {
+	query := `SELECT r.id, r.name, r.description,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}') AS permissions
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		GROUP BY r.id
+		ORDER BY r.name`
+
+	res := []roleModel.Role{}
+	err := r.db.Select(
+		ctx,
+		&res,
+		query,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error get roles", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+func (r RoleRepo) GetRoleByName(ctx context.Context, name string) (roleModel.Role, error) {
+	query := `SELECT r.id, r.name, r.description,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}') AS permissions
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		WHERE r.name = $1
+		GROUP BY r.id`
+
+	res := roleModel.Role{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		name,
+	)
+
+	if err != nil {
+		if err == database.RecordNotFound {
+			return roleModel.Role{}, roleModel.ErrorRoleNotFound
+		}
+
+		log.Error(ctx, "error get role by name", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+func (r RoleRepo) GetPermissionByName(ctx context.Context, name string) (roleModel.Permission, error) {
+	query := `SELECT id, name, description
+		FROM permissions
+		WHERE name = $1`
+
+	res := roleModel.Permission{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		name,
+	)
+
+	if err != nil {
+		if err == database.RecordNotFound {
+			return roleModel.Permission{}, roleModel.ErrorPermissionNotFound
+		}
+
+		log.Error(ctx, "error get permission by name", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+func (r RoleRepo) GrantPermissionTx(ctx context.Context, tx pgx.Tx, args roleModel.InsertRolePermission) error {
+	query := `INSERT INTO role_permissions (role_id, permission_id, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (role_id, permission_id) DO NOTHING;`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		args.RoleID,
+		args.PermissionID,
+		args.Actor,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error grant permission", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+func (r RoleRepo) AssignUserRoleTx(ctx context.Context, tx pgx.Tx, args roleModel.InsertUserRole) error {
+	query := `INSERT INTO user_roles (user_id, role_id, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role_id) DO NOTHING;`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		args.UserID,
+		args.RoleID,
+		args.Actor,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error assign user role", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+func (r RoleRepo) RemoveUserRoleTx(ctx context.Context, tx pgx.Tx, userID string, roleID string) error {
+	query := `DELETE FROM user_roles
+		WHERE user_id = $1 AND role_id = $2`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+		roleID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error remove user role", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+func (r RoleRepo) GetUserAuthorization(ctx context.Context, userID string) (roleModel.UserAuthorization, error) {
+	query := `SELECT
+			COALESCE(array_agg(DISTINCT r.name) FILTER (WHERE r.name IS NOT NULL), '{}') AS roles,
+			COALESCE(array_agg(DISTINCT p.name) FILTER (WHERE p.name IS NOT NULL), '{}') AS permissions
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		WHERE ur.user_id = $1`
+
+	res := roleModel.UserAuthorization{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		userID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error get user authorization", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
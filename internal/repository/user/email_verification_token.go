@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"golang-rest-api/internal/model"
+	userModel "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IEmailVerificationTokenRepo interface {
+	CreateEmailVerificationTokenTx(ctx context.Context, tx pgx.Tx, args userModel.InsertEmailVerificationToken) error
+	ConsumeEmailVerificationTokenTx(ctx context.Context, tx pgx.Tx, tokenHash string) (userModel.EmailVerificationToken, error)
+	InvalidateUserEmailVerificationTokensTx(ctx context.Context, tx pgx.Tx, userID string) error
+	HasEmailVerificationTokenCreatedSince(ctx context.Context, userID string, email string, since time.Time) (bool, error)
+}
+
+type EmailVerificationTokenRepo struct {
+	db database.IPostgres
+}
+
+func NewEmailVerificationTokenRepo(db database.IPostgres) *EmailVerificationTokenRepo {
+	return &EmailVerificationTokenRepo{
+		db: db,
+	}
+}
+
+func (r EmailVerificationTokenRepo) CreateEmailVerificationTokenTx(ctx context.Context, tx pgx.Tx, args userModel.InsertEmailVerificationToken) error {
+	query := `INSERT INTO email_verification_tokens (id, user_id, email, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5);`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		args.ID,
+		args.UserID,
+		args.Email,
+		args.TokenHash,
+		args.ExpiresAt,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error create email verification token", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+// ConsumeEmailVerificationTokenTx mark token as used and return user and email it was issued for,
+// return ErrorEmailVerificationTokenInvalid when token not exist, already used or expired
+func (r EmailVerificationTokenRepo) ConsumeEmailVerificationTokenTx(ctx context.Context, tx pgx.Tx, tokenHash string) (userModel.EmailVerificationToken, error) {
+	query := `UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id, email`
+
+	res := userModel.EmailVerificationToken{}
+	err := tx.QueryRow(
+		ctx,
+		query,
+		tokenHash,
+	).Scan(&res.UserID, &res.Email)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return userModel.EmailVerificationToken{}, userModel.ErrorEmailVerificationTokenInvalid
+		}
+
+		log.Error(ctx, "error consume email verification token", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+func (r EmailVerificationTokenRepo) InvalidateUserEmailVerificationTokensTx(ctx context.Context, tx pgx.Tx, userID string) error {
+	query := `UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error invalidate user email verification tokens", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+// HasEmailVerificationTokenCreatedSince return whether user has unused token for email created after since
+func (r EmailVerificationTokenRepo) HasEmailVerificationTokenCreatedSince(ctx context.Context, userID string, email string, since time.Time) (bool, error) {
+	query := `SELECT EXISTS(
+			SELECT 1 FROM email_verification_tokens
+			WHERE user_id = $1 AND email = $2 AND used_at IS NULL AND created_at > $3
+		)`
+
+	var exists bool
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		userID,
+		email,
+		since,
+	).Scan(&exists)
+
+	if err != nil {
+		log.Error(ctx, "error check recent email verification token", err)
+		return false, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"golang-rest-api/internal/model"
+	userModel "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IPasswordResetTokenRepo interface {
+	CreatePasswordResetTokenTx(ctx context.Context, tx pgx.Tx, args userModel.InsertPasswordResetToken) error
+	ConsumePasswordResetTokenTx(ctx context.Context, tx pgx.Tx, tokenHash string) (string, error)
+	InvalidateUserPasswordResetTokensTx(ctx context.Context, tx pgx.Tx, userID string) error
+	HasPasswordResetTokenCreatedSince(ctx context.Context, userID string, since time.Time) (bool, error)
+}
+
+type PasswordResetTokenRepo struct {
+	db database.IPostgres
+}
+
+func NewPasswordResetTokenRepo(db database.IPostgres) *PasswordResetTokenRepo {
+	return &PasswordResetTokenRepo{
+		db: db,
+	}
+}
+
+func (r PasswordResetTokenRepo) CreatePasswordResetTokenTx(ctx context.Context, tx pgx.Tx, args userModel.InsertPasswordResetToken) error {
+	query := `INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4);`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		args.ID,
+		args.UserID,
+		args.TokenHash,
+		args.ExpiresAt,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error create password reset token", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+// ConsumePasswordResetTokenTx mark token as used and return its user id,
+// return ErrorPasswordResetTokenInvalid when token not exist, already used or expired
+func (r PasswordResetTokenRepo) ConsumePasswordResetTokenTx(ctx context.Context, tx pgx.Tx, tokenHash string) (string, error) {
+	query := `UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id`
+
+	var userID string
+	err := tx.QueryRow(
+		ctx,
+		query,
+		tokenHash,
+	).Scan(&userID)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", userModel.ErrorPasswordResetTokenInvalid
+		}
+
+		log.Error(ctx, "error consume password reset token", err)
+		return "", pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return userID, nil
+}
+
+func (r PasswordResetTokenRepo) InvalidateUserPasswordResetTokensTx(ctx context.Context, tx pgx.Tx, userID string) error {
+	query := `UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error invalidate user password reset tokens", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+// HasPasswordResetTokenCreatedSince return whether user has unused token created after since
+func (r PasswordResetTokenRepo) HasPasswordResetTokenCreatedSince(ctx context.Context, userID string, since time.Time) (bool, error) {
+	query := `SELECT EXISTS(
+			SELECT 1 FROM password_reset_tokens
+			WHERE user_id = $1 AND used_at IS NULL AND created_at > $2
+		)`
+
+	var exists bool
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		userID,
+		since,
+	).Scan(&exists)
+
+	if err != nil {
+		log.Error(ctx, "error check recent password reset token", err)
+		return false, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return exists, nil
+}
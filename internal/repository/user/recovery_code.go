@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"golang-rest-api/internal/model"
+	userModel "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IRecoveryCodeRepo interface {
+	CreateRecoveryCodesTx(ctx context.Context, tx pgx.Tx, args []userModel.InsertRecoveryCode) error
+	ConsumeRecoveryCodeTx(ctx context.Context, tx pgx.Tx, userID string, codeHash string) error
+	DeleteUserRecoveryCodesTx(ctx context.Context, tx pgx.Tx, userID string) error
+}
+
+type RecoveryCodeRepo struct {
+	db database.IPostgres
+}
+
+func NewRecoveryCodeRepo(db database.IPostgres) *RecoveryCodeRepo {
+	return &RecoveryCodeRepo{
+		db: db,
+	}
+}
+
+func (r RecoveryCodeRepo) CreateRecoveryCodesTx(ctx context.Context, tx pgx.Tx, args []userModel.InsertRecoveryCode) error {
+	query := `INSERT INTO user_recovery_codes (id, user_id, code_hash)
+		VALUES ($1, $2, $3);`
+
+	batch := &pgx.Batch{}
+	for _, arg := range args {
+		batch.Queue(query, arg.ID, arg.UserID, arg.CodeHash)
+	}
+
+	err := tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		log.Error(ctx, "error create recovery codes", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCodeTx mark recovery code as used,
+// return ErrorMFACodeInvalid when code not exist or already used
+func (r RecoveryCodeRepo) ConsumeRecoveryCodeTx(ctx context.Context, tx pgx.Tx, userID string, codeHash string) error {
+	query := `UPDATE user_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+		codeHash,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error consume recovery code", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorMFACodeInvalid
+	}
+
+	return nil
+}
+
+func (r RecoveryCodeRepo) DeleteUserRecoveryCodesTx(ctx context.Context, tx pgx.Tx, userID string) error {
+	query := `DELETE FROM user_recovery_codes
+		WHERE user_id = $1`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error delete user recovery codes", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package user
+
+import (
+	"context"
+	"golang-rest-api/internal/model"
+	userModel "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ISessionRepo interface {
+	CreateSessionTx(ctx context.Context, tx pgx.Tx, args userModel.InsertSession) error
+	GetSessionByID(ctx context.Context, ID string) (userModel.Session, error)
+	RotateSessionTx(ctx context.Context, tx pgx.Tx, ID string, replacedBy string) error
+	RevokeSessionFamily(ctx context.Context, familyID string) error
+	RevokeUserSessionsTx(ctx context.Context, tx pgx.Tx, userID string, exceptFamilyID string) error
+}
+
+type SessionRepo struct {
+	db database.IPostgres
+}
+
+func NewSessionRepo(db database.IPostgres) *SessionRepo {
+	return &SessionRepo{
+		db: db,
+	}
+}
+
+func (r SessionRepo) CreateSessionTx(ctx context.Context, tx pgx.Tx, args userModel.InsertSession) error {
+	query := `INSERT INTO sessions (id, family_id, user_id, expires_at)
+		VALUES ($1, $2, $3, $4);`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		args.ID,
+		args.FamilyID,
+		args.UserID,
+		args.ExpiresAt,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error create session", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+func (r SessionRepo) GetSessionByID(ctx context.Context, ID string) (userModel.Session, error) {
+	query := `SELECT id, family_id, user_id, expires_at, rotated_at, replaced_by, revoked_at
+		FROM sessions
+		WHERE id = $1`
+
+	res := userModel.Session{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		ID,
+	)
+
+	if err != nil {
+		if err == database.RecordNotFound {
+			return userModel.Session{}, userModel.ErrorSessionNotFound
+		}
+
+		log.Error(ctx, "error get session by id", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+// RotateSessionTx mark session as rotated and replaced by new session,
+// return ErrorRefreshTokenReused when session already rotated or revoked
+func (r SessionRepo) RotateSessionTx(ctx context.Context, tx pgx.Tx, ID string, replacedBy string) error {
+	query := `UPDATE sessions
+		SET rotated_at = NOW(), replaced_by = $2
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		replacedBy,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error rotate session", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorRefreshTokenReused
+	}
+
+	return nil
+}
+
+func (r SessionRepo) RevokeSessionFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL`
+
+	_, err := r.db.Exec(
+		ctx,
+		query,
+		familyID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error revoke session family", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
+
+// RevokeUserSessionsTx revoke every active session of user except session in exceptFamilyID family,
+// exceptFamilyID can be empty to revoke all sessions
+func (r SessionRepo) RevokeUserSessionsTx(ctx context.Context, tx pgx.Tx, userID string, exceptFamilyID string) error {
+	query := `UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND family_id::text <> $2 AND revoked_at IS NULL`
+
+	_, err := tx.Exec(
+		ctx,
+		query,
+		userID,
+		exceptFamilyID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error revoke user sessions", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return nil
+}
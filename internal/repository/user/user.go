@@ -2,12 +2,14 @@ package user
 
 import (
 	"context"
+	"fmt"
 	"golang-rest-api/internal/model"
 	"golang-rest-api/internal/model/user"
 	userModel "golang-rest-api/internal/model/user"
 	"golang-rest-api/pkg/database"
 	pkgErr "golang-rest-api/pkg/error"
 	"golang-rest-api/pkg/log"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -15,8 +17,23 @@ import (
 
 type IUserRepo interface {
 	CreateUserTx(ctx context.Context, tx pgx.Tx, args userModel.InsertUser) error
+	UpdateUserTx(ctx context.Context, tx pgx.Tx, args userModel.UpdateUser) error
+	UpdateUserPasswordTx(ctx context.Context, tx pgx.Tx, args userModel.UpdateUserPassword) error
 	GetUserByID(ctx context.Context, ID string) (userModel.User, error)
 	GetUserByUsername(ctx context.Context, username string) (userModel.User, error)
+	GetUserByEmail(ctx context.Context, email string) (userModel.User, error)
+	GetUserDetailByID(ctx context.Context, ID string) (userModel.UserDetail, error)
+	MarkEmailVerifiedTx(ctx context.Context, tx pgx.Tx, ID string, email string) error
+	SoftDeleteUserTx(ctx context.Context, tx pgx.Tx, ID string, actor string) error
+	RestoreUserTx(ctx context.Context, tx pgx.Tx, ID string, actor string) error
+	IsUserActive(ctx context.Context, ID string) (bool, error)
+	GetUserMFA(ctx context.Context, ID string) (userModel.UserMFA, error)
+	SetUserMFASecretTx(ctx context.Context, tx pgx.Tx, ID string, secret string) error
+	EnableUserMFATx(ctx context.Context, tx pgx.Tx, ID string, counter int64) error
+	DisableUserMFATx(ctx context.Context, tx pgx.Tx, ID string) error
+	UseUserMFACounterTx(ctx context.Context, tx pgx.Tx, ID string, counter int64) error
+	ListUsers(ctx context.Context, filter userModel.ListUsersFilter) ([]userModel.User, int, error)
+	ListUsersByCursor(ctx context.Context, filter userModel.ListUsersFilter, cursor *database.Cursor) ([]userModel.User, error)
 }
 
 type UserRepo struct {
@@ -30,8 +47,8 @@ func NewUserRepo(db database.IPostgres) *UserRepo {
 }
 
 func (r UserRepo) CreateUserTx(ctx context.Context, tx pgx.Tx, args user.InsertUser) error {
-	query := `INSERT INTO users (id, name, username, phone, password, created_by) 
-		VALUES ($1, $2, $3, $4, $5, $6);`
+	query := `INSERT INTO users (id, name, username, phone, email, password, created_by) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`
 
 	_, err := tx.Exec(
 		ctx,
@@ -40,6 +57,7 @@ func (r UserRepo) CreateUserTx(ctx context.Context, tx pgx.Tx, args user.InsertU
 		args.Name,
 		args.Username,
 		args.Phone,
+		args.Email,
 		args.Password,
 		args.Actor,
 	)
@@ -53,6 +71,8 @@ func (r UserRepo) CreateUserTx(ctx context.Context, tx pgx.Tx, args user.InsertU
 				switch errPg.ConstraintName {
 				case "user_unique_username":
 					return userModel.ErrorDuplicateUsername
+				case "user_unique_email":
+					return userModel.ErrorDuplicateEmail
 				}
 			}
 		}
@@ -63,8 +83,79 @@ func (r UserRepo) CreateUserTx(ctx context.Context, tx pgx.Tx, args user.InsertU
 	return nil
 }
 
+// UpdateUserTx update user field which is not nil
+func (r UserRepo) UpdateUserTx(ctx context.Context, tx pgx.Tx, args userModel.UpdateUser) error {
+	query := `UPDATE users
+		SET name = COALESCE($2, name),
+			phone = COALESCE($3, phone),
+			email = COALESCE($4, email),
+			email_verified_at = CASE WHEN $4::varchar IS NULL OR $4 = email THEN email_verified_at ELSE NULL END,
+			updated_at = NOW(),
+			updated_by = $5
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		args.ID,
+		args.Name,
+		args.Phone,
+		args.Email,
+		args.Actor,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error update user", err)
+
+		if errPg, ok := err.(*pgconn.PgError); ok {
+			switch errPg.Code {
+			case "23505":
+				switch errPg.ConstraintName {
+				case "user_unique_email":
+					return userModel.ErrorDuplicateEmail
+				}
+			}
+		}
+
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorUserNotFound
+	}
+
+	return nil
+}
+
+func (r UserRepo) UpdateUserPasswordTx(ctx context.Context, tx pgx.Tx, args userModel.UpdateUserPassword) error {
+	query := `UPDATE users
+		SET password = $2,
+			updated_at = NOW(),
+			updated_by = $3
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		args.ID,
+		args.Password,
+		args.Actor,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error update user password", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorUserNotFound
+	}
+
+	return nil
+}
+
 func (r UserRepo) GetUserByID(ctx context.Context, ID string) (userModel.User, error) {
-	query := `SELECT id, name, username, phone, password
+	query := `SELECT id, name, username, phone, email, email_verified_at, password, mfa_enabled_at
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL`
 
@@ -81,6 +172,11 @@ func (r UserRepo) GetUserByID(ctx context.Context, ID string) (userModel.User, e
 			return userModel.User{}, userModel.ErrorUserNotFound
 		}
 
+		// id which is not a valid uuid can not belong to any user
+		if errPg, ok := err.(*pgconn.PgError); ok && errPg.Code == "22P02" {
+			return userModel.User{}, userModel.ErrorUserNotFound
+		}
+
 		log.Error(ctx, "error get user by id", err)
 		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
 	}
@@ -89,7 +185,7 @@ func (r UserRepo) GetUserByID(ctx context.Context, ID string) (userModel.User, e
 }
 
 func (r UserRepo) GetUserByUsername(ctx context.Context, username string) (userModel.User, error) {
-	query := `SELECT id, name, username, phone, password
+	query := `SELECT id, name, username, phone, email, email_verified_at, password, mfa_enabled_at
 		FROM users
 		WHERE username = $1 AND deleted_at IS NULL`
 
@@ -112,3 +208,452 @@ func (r UserRepo) GetUserByUsername(ctx context.Context, username string) (userM
 
 	return res, nil
 }
+
+func (r UserRepo) GetUserByEmail(ctx context.Context, email string) (userModel.User, error) {
+	query := `SELECT id, name, username, phone, email, email_verified_at, password, mfa_enabled_at
+		FROM users
+		WHERE email = $1 AND deleted_at IS NULL`
+
+	res := userModel.User{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		email,
+	)
+
+	if err != nil {
+		if err == database.RecordNotFound {
+			return userModel.User{}, userModel.ErrorUserNotFound
+		}
+
+		log.Error(ctx, "error get user by email", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+// GetUserDetailByID return user including deleted one with its audit fields
+func (r UserRepo) GetUserDetailByID(ctx context.Context, ID string) (userModel.UserDetail, error) {
+	query := `SELECT id, name, username, phone, email, email_verified_at, mfa_enabled_at,
+			created_at, created_by, updated_at, updated_by, deleted_at, deleted_by
+		FROM users
+		WHERE id = $1`
+
+	res := userModel.UserDetail{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		ID,
+	)
+
+	if err != nil {
+		if err == database.RecordNotFound {
+			return userModel.UserDetail{}, userModel.ErrorUserNotFound
+		}
+
+		if errPg, ok := err.(*pgconn.PgError); ok && errPg.Code == "22P02" {
+			return userModel.UserDetail{}, userModel.ErrorUserNotFound
+		}
+
+		log.Error(ctx, "error get user detail by id", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+// MarkEmailVerifiedTx mark email of user as verified,
+// return ErrorEmailVerificationTokenInvalid when user email has changed since the token was issued
+func (r UserRepo) MarkEmailVerifiedTx(ctx context.Context, tx pgx.Tx, ID string, email string) error {
+	query := `UPDATE users
+		SET email_verified_at = NOW(),
+			updated_at = NOW(),
+			updated_by = $1
+		WHERE id = $1 AND email = $2 AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		email,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error mark email verified", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorEmailVerificationTokenInvalid
+	}
+
+	return nil
+}
+
+func (r UserRepo) SoftDeleteUserTx(ctx context.Context, tx pgx.Tx, ID string, actor string) error {
+	query := `UPDATE users
+		SET deleted_at = NOW(),
+			deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		actor,
+	)
+
+	if err != nil {
+		if errPg, ok := err.(*pgconn.PgError); ok && errPg.Code == "22P02" {
+			return userModel.ErrorUserNotFound
+		}
+
+		log.Error(ctx, "error soft delete user", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorUserNotFound
+	}
+
+	return nil
+}
+
+// RestoreUserTx clear deletion of user, return ErrorDeletedUserNotFound when user is not deleted
+func (r UserRepo) RestoreUserTx(ctx context.Context, tx pgx.Tx, ID string, actor string) error {
+	query := `UPDATE users
+		SET deleted_at = NULL,
+			deleted_by = NULL,
+			updated_at = NOW(),
+			updated_by = $2
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		actor,
+	)
+
+	if err != nil {
+		if errPg, ok := err.(*pgconn.PgError); ok && errPg.Code == "22P02" {
+			return userModel.ErrorDeletedUserNotFound
+		}
+
+		log.Error(ctx, "error restore user", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorDeletedUserNotFound
+	}
+
+	return nil
+}
+
+// IsUserActive return false when user not exist or has been deleted
+func (r UserRepo) IsUserActive(ctx context.Context, ID string) (bool, error) {
+	query := `SELECT EXISTS(
+			SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL
+		)`
+
+	var res bool
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		ID,
+	)
+
+	if err != nil {
+		// id which is not a valid uuid can not belong to any user
+		if errPg, ok := err.(*pgconn.PgError); ok && errPg.Code == "22P02" {
+			return false, nil
+		}
+
+		log.Error(ctx, "error check user active", err)
+		return false, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+var listUsersSortColumns = map[string]string{
+	"created_at": "created_at",
+	"username":   "username",
+	"name":       "name",
+}
+
+// ListUsers return users matching filter and total of matching users regardless of limit and offset
+func (r UserRepo) ListUsers(ctx context.Context, filter userModel.ListUsersFilter) ([]userModel.User, int, error) {
+	conditions, args := listUsersConditions(filter)
+	where := listUsersWhere(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM users ` + where
+
+	var total int
+	err := r.db.Get(
+		ctx,
+		&total,
+		countQuery,
+		args...,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error count users", err)
+		return nil, 0, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	sortColumn, ok := listUsersSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = "created_at"
+	}
+
+	sortOrder := "DESC"
+	if filter.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT id, name, username, phone, email, email_verified_at, created_at, deleted_at
+		FROM users
+		%s
+		ORDER BY %s %s, id %s
+		LIMIT $%d OFFSET $%d`, where, sortColumn, sortOrder, sortOrder, len(args)-1, len(args))
+
+	res := []userModel.User{}
+	err = r.db.Select(
+		ctx,
+		&res,
+		query,
+		args...,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error list users", err)
+		return nil, 0, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, total, nil
+}
+
+// ListUsersByCursor return up to filter.Limit users matching filter ordered by (created_at, id)
+// after the cursor, nil cursor start from the first user, filter.Offset and filter.SortBy are ignored
+func (r UserRepo) ListUsersByCursor(ctx context.Context, filter userModel.ListUsersFilter, cursor *database.Cursor) ([]userModel.User, error) {
+	conditions, args := listUsersConditions(filter)
+
+	desc := filter.SortOrder != "asc"
+	if cursor != nil {
+		condition, cursorArgs := cursor.Condition("created_at", "id", desc, len(args)+1)
+		conditions = append(conditions, condition)
+		args = append(args, cursorArgs...)
+	}
+
+	sortOrder := "DESC"
+	if !desc {
+		sortOrder = "ASC"
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`SELECT id, name, username, phone, email, email_verified_at, created_at, deleted_at
+		FROM users
+		%s
+		ORDER BY created_at %s, id %s
+		LIMIT $%d`, listUsersWhere(conditions), sortOrder, sortOrder, len(args))
+
+	res := []userModel.User{}
+	err := r.db.Select(
+		ctx,
+		&res,
+		query,
+		args...,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error list users by cursor", err)
+		return nil, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+func listUsersConditions(filter userModel.ListUsersFilter) ([]string, []any) {
+	conditions := []string{}
+	args := []any{}
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if len(filter.Username) > 0 {
+		addCondition("username LIKE $%d || '%%'", escapeLike(filter.Username))
+	}
+	if len(filter.Name) > 0 {
+		addCondition("name ILIKE $%d || '%%'", escapeLike(filter.Name))
+	}
+	if len(filter.Phone) > 0 {
+		addCondition("phone LIKE $%d || '%%'", escapeLike(filter.Phone))
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition("created_at < $%d", *filter.CreatedTo)
+	}
+
+	return conditions, args
+}
+
+func listUsersWhere(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// escapeLike escape LIKE wildcard so value is matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r UserRepo) GetUserMFA(ctx context.Context, ID string) (userModel.UserMFA, error) {
+	query := `SELECT mfa_secret, mfa_enabled_at, mfa_last_used_counter
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	res := userModel.UserMFA{}
+	err := r.db.Get(
+		ctx,
+		&res,
+		query,
+		ID,
+	)
+
+	if err != nil {
+		if err == database.RecordNotFound {
+			return userModel.UserMFA{}, userModel.ErrorUserNotFound
+		}
+
+		log.Error(ctx, "error get user mfa", err)
+		return res, pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	return res, nil
+}
+
+// SetUserMFASecretTx store pending secret of user which has not enabled MFA,
+// return ErrorMFAAlreadyEnabled when MFA is already enabled
+func (r UserRepo) SetUserMFASecretTx(ctx context.Context, tx pgx.Tx, ID string, secret string) error {
+	query := `UPDATE users
+		SET mfa_secret = $2,
+			mfa_last_used_counter = 0,
+			updated_at = NOW(),
+			updated_by = $1
+		WHERE id = $1 AND mfa_enabled_at IS NULL AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		secret,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error set user mfa secret", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+// EnableUserMFATx enable MFA of user which has enrolled secret, counter is time step of the confirmation code
+func (r UserRepo) EnableUserMFATx(ctx context.Context, tx pgx.Tx, ID string, counter int64) error {
+	query := `UPDATE users
+		SET mfa_enabled_at = NOW(),
+			mfa_last_used_counter = $2,
+			updated_at = NOW(),
+			updated_by = $1
+		WHERE id = $1 AND mfa_secret IS NOT NULL AND mfa_enabled_at IS NULL AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		counter,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error enable user mfa", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorMFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+func (r UserRepo) DisableUserMFATx(ctx context.Context, tx pgx.Tx, ID string) error {
+	query := `UPDATE users
+		SET mfa_secret = NULL,
+			mfa_enabled_at = NULL,
+			mfa_last_used_counter = 0,
+			updated_at = NOW(),
+			updated_by = $1
+		WHERE id = $1 AND mfa_enabled_at IS NOT NULL AND deleted_at IS NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error disable user mfa", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorMFANotEnabled
+	}
+
+	return nil
+}
+
+// UseUserMFACounterTx record time step of used TOTP code,
+// return ErrorMFACodeInvalid when code of the same or later time step has been used so code can not be replayed
+func (r UserRepo) UseUserMFACounterTx(ctx context.Context, tx pgx.Tx, ID string, counter int64) error {
+	query := `UPDATE users
+		SET mfa_last_used_counter = $2
+		WHERE id = $1 AND mfa_last_used_counter < $2 AND mfa_enabled_at IS NOT NULL`
+
+	cmdTag, err := tx.Exec(
+		ctx,
+		query,
+		ID,
+		counter,
+	)
+
+	if err != nil {
+		log.Error(ctx, "error use user mfa counter", err)
+		return pkgErr.NewCustomErrWithOriginalErr(model.ErrorExecQuery, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return userModel.ErrorMFACodeInvalid
+	}
+
+	return nil
+}
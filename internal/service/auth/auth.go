@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	modelAuth "golang-rest-api/internal/model/auth"
+	repoUser "golang-rest-api/internal/repository/user"
+	"golang-rest-api/pkg/jwt"
+)
+
+type IAuthService interface {
+	Introspect(ctx context.Context, req modelAuth.IntrospectReq) (modelAuth.IntrospectResp, error)
+}
+
+type AuthServiceOption func(*AuthService)
+
+func WithJWTParser(jwtParser jwt.JWTParser) AuthServiceOption {
+	return func(as *AuthService) {
+		as.jwtParser = jwtParser
+	}
+}
+
+func WithJWTRevocationStore(revocationStore jwt.JWTRevocationStore) AuthServiceOption {
+	return func(as *AuthService) {
+		as.jwtRevocationStore = revocationStore
+	}
+}
+
+func WithSessionRepo(sessionRepo repoUser.ISessionRepo) AuthServiceOption {
+	return func(as *AuthService) {
+		as.sessionRepo = sessionRepo
+	}
+}
+
+func WithUserRepo(userRepo repoUser.IUserRepo) AuthServiceOption {
+	return func(as *AuthService) {
+		as.userRepo = userRepo
+	}
+}
+
+type AuthService struct {
+	jwtParser          jwt.JWTParser
+	jwtRevocationStore jwt.JWTRevocationStore
+	sessionRepo        repoUser.ISessionRepo
+	userRepo           repoUser.IUserRepo
+}
+
+func NewAuthService(options ...AuthServiceOption) AuthService {
+	res := &AuthService{}
+
+	for _, apply := range options {
+		apply(res)
+	}
+
+	return *res
+}
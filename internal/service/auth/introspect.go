@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	modelAuth "golang-rest-api/internal/model/auth"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/jwt"
+	"strings"
+)
+
+// Introspect return state of token as described in RFC 7662, invalid, expired,
+// revoked or deleted user token is reported as inactive instead of error
+func (s AuthService) Introspect(ctx context.Context, req modelAuth.IntrospectReq) (modelAuth.IntrospectResp, error) {
+	inactive := modelAuth.IntrospectResp{Active: false}
+
+	claims, err := s.jwtParser.ParseAndValidate(ctx, req.Token)
+	if err != nil {
+		return inactive, nil
+	}
+
+	switch claims.TokenType {
+	case jwt.JWTTokenTypeAccess:
+		revoked, err := s.jwtRevocationStore.IsRevoked(ctx, claims.ID)
+		if err != nil {
+			return modelAuth.IntrospectResp{}, err
+		}
+
+		if revoked {
+			return inactive, nil
+		}
+
+	case jwt.JWTTokenTypeRefresh:
+		session, err := s.sessionRepo.GetSessionByID(ctx, claims.ID)
+		if err != nil {
+			if err == modelUser.ErrorSessionNotFound {
+				return inactive, nil
+			}
+
+			return modelAuth.IntrospectResp{}, err
+		}
+
+		if session.RevokedAt != nil || session.RotatedAt != nil {
+			return inactive, nil
+		}
+
+	default:
+		return inactive, nil
+	}
+
+	active, err := s.userRepo.IsUserActive(ctx, claims.Subject)
+	if err != nil {
+		return modelAuth.IntrospectResp{}, err
+	}
+
+	if !active {
+		return inactive, nil
+	}
+
+	return modelAuth.IntrospectResp{
+		Active:    true,
+		Scope:     strings.Join(claims.Permissions, " "),
+		TokenType: string(claims.TokenType),
+		ExpiresAt: claims.ExpireAt.Unix(),
+		IssuedAt:  claims.IssuedAt.Unix(),
+		Subject:   claims.Subject,
+		Audience:  claims.Audience,
+		Issuer:    claims.Issuer,
+		JTI:       claims.ID,
+
+		AuthMethods: claims.AuthMethods,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package role
+
+import (
+	"context"
+	modelRole "golang-rest-api/internal/model/role"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s RoleService) CreateRole(ctx context.Context, req modelRole.CreateRoleReq) (modelRole.RoleResp, error) {
+	insertRoleArgs := modelRole.InsertRole{
+		ID:          s.uuidGenerator(),
+		Name:        req.Name,
+		Description: req.Description,
+		Actor:       req.Actor,
+	}
+
+	err := s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.roleRepo.CreateRoleTx(ctx, tx, insertRoleArgs)
+	})
+	if err != nil {
+		return modelRole.RoleResp{}, err
+	}
+
+	return modelRole.RoleResp{
+		ID:          insertRoleArgs.ID,
+		Name:        insertRoleArgs.Name,
+		Description: insertRoleArgs.Description,
+		Permissions: []string{},
+	}, nil
+}
+
+func (s RoleService) GetRoles(ctx context.Context) ([]modelRole.RoleResp, error) {
+	roles, err := s.roleRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]modelRole.RoleResp, 0, len(roles))
+	for _, r := range roles {
+		res = append(res, modelRole.RoleResp{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+			Permissions: r.Permissions,
+		})
+	}
+
+	return res, nil
+}
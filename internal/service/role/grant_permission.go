@@ -0,0 +1,43 @@
+package role
+
+import (
+	"context"
+	modelRole "golang-rest-api/internal/model/role"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s RoleService) GrantPermission(ctx context.Context, req modelRole.GrantPermissionReq) (modelRole.RoleResp, error) {
+	r, err := s.roleRepo.GetRoleByName(ctx, req.RoleName)
+	if err != nil {
+		return modelRole.RoleResp{}, err
+	}
+
+	p, err := s.roleRepo.GetPermissionByName(ctx, req.Permission)
+	if err != nil {
+		return modelRole.RoleResp{}, err
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.roleRepo.GrantPermissionTx(ctx, tx, modelRole.InsertRolePermission{
+			RoleID:       r.ID,
+			PermissionID: p.ID,
+			Actor:        req.Actor,
+		})
+	})
+	if err != nil {
+		return modelRole.RoleResp{}, err
+	}
+
+	r, err = s.roleRepo.GetRoleByName(ctx, req.RoleName)
+	if err != nil {
+		return modelRole.RoleResp{}, err
+	}
+
+	return modelRole.RoleResp{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Permissions,
+	}, nil
+}
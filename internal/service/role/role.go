@@ -0,0 +1,58 @@
+package role
+
+import (
+	"context"
+	modelRole "golang-rest-api/internal/model/role"
+	repoRole "golang-rest-api/internal/repository/role"
+	repoUser "golang-rest-api/internal/repository/user"
+	"golang-rest-api/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+type IRoleService interface {
+	CreateRole(ctx context.Context, req modelRole.CreateRoleReq) (modelRole.RoleResp, error)
+	GetRoles(ctx context.Context) ([]modelRole.RoleResp, error)
+	GrantPermission(ctx context.Context, req modelRole.GrantPermissionReq) (modelRole.RoleResp, error)
+	AssignUserRole(ctx context.Context, req modelRole.AssignUserRoleReq) error
+	RemoveUserRole(ctx context.Context, req modelRole.RemoveUserRoleReq) error
+}
+
+type RoleServiceOption func(*RoleService)
+
+func WithRoleRepo(roleRepo repoRole.IRoleRepo) RoleServiceOption {
+	return func(rs *RoleService) {
+		rs.roleRepo = roleRepo
+	}
+}
+
+func WithUserRepo(userRepo repoUser.IUserRepo) RoleServiceOption {
+	return func(rs *RoleService) {
+		rs.userRepo = userRepo
+	}
+}
+
+func WithTxHandler(db database.IPostgres) RoleServiceOption {
+	return func(rs *RoleService) {
+		rs.txHandler = database.NewTxHandler(db)
+	}
+}
+
+type RoleService struct {
+	roleRepo      repoRole.IRoleRepo
+	userRepo      repoUser.IUserRepo
+	txHandler     database.TxHandler
+	uuidGenerator func() string
+}
+
+func NewRoleService(options ...RoleServiceOption) RoleService {
+	res := &RoleService{
+		uuidGenerator: uuid.NewString,
+	}
+
+	for _, apply := range options {
+		apply(res)
+	}
+
+	return *res
+}
@@ -0,0 +1,45 @@
+package role
+
+import (
+	"context"
+	modelRole "golang-rest-api/internal/model/role"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AssignUserRole assign role to user, the role is applied on the next token issued for the user
+func (s RoleService) AssignUserRole(ctx context.Context, req modelRole.AssignUserRoleReq) error {
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.roleRepo.GetRoleByName(ctx, req.Role)
+	if err != nil {
+		return err
+	}
+
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.roleRepo.AssignUserRoleTx(ctx, tx, modelRole.InsertUserRole{
+			UserID: u.ID,
+			RoleID: r.ID,
+			Actor:  req.Actor,
+		})
+	})
+}
+
+func (s RoleService) RemoveUserRole(ctx context.Context, req modelRole.RemoveUserRoleReq) error {
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.roleRepo.GetRoleByName(ctx, req.Role)
+	if err != nil {
+		return err
+	}
+
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.roleRepo.RemoveUserRoleTx(ctx, tx, u.ID, r.ID)
+	})
+}
@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/jwt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ChangePassword change password of user and revoke every other session of the user,
+// session of req.RefreshToken is kept so user stay logged in on current device
+func (s UserService) ChangePassword(ctx context.Context, req modelUser.ChangePasswordReq) error {
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.confirmPassword(ctx, u, req.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	err = s.passwordPolicy.Check(ctx, req.NewPassword, u.Username)
+	if err != nil {
+		return err
+	}
+
+	hashPwdBytes, err := s.crypter.GenerateHash(ctx, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	currentFamilyID := s.currentSessionFamilyID(ctx, u.ID, req.RefreshToken)
+
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.userRepo.UpdateUserPasswordTx(ctx, tx, modelUser.UpdateUserPassword{
+			ID:       u.ID,
+			Password: string(hashPwdBytes),
+			Actor:    u.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.sessionRepo.RevokeUserSessionsTx(ctx, tx, u.ID, currentFamilyID)
+	})
+}
+
+// currentSessionFamilyID return session family of refresh token owned by user,
+// empty when refresh token is not valid
+func (s UserService) currentSessionFamilyID(ctx context.Context, userID string, refreshToken string) string {
+	if len(refreshToken) == 0 {
+		return ""
+	}
+
+	claims, err := s.jwtParser.ParseAndValidateWithTokenType(ctx, refreshToken, jwt.JWTTokenTypeRefresh)
+	if err != nil {
+		return ""
+	}
+
+	session, err := s.sessionRepo.GetSessionByID(ctx, claims.ID)
+	if err != nil || session.UserID != userID || session.RevokedAt != nil {
+		return ""
+	}
+
+	return session.FamilyID
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 
 	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/log"
 
 	"github.com/jackc/pgx/v5"
 )
 
 func (s UserService) CreateUser(ctx context.Context, req modelUser.CreateUserReq) (modelUser.CreateUserResp, error) {
+	err := s.passwordPolicy.Check(ctx, req.Password, req.Username)
+	if err != nil {
+		return modelUser.CreateUserResp{}, err
+	}
+
 	hashPwdBytes, err := s.crypter.GenerateHash(ctx, req.Password)
 	if err != nil {
 		return modelUser.CreateUserResp{}, err
@@ -22,23 +28,43 @@ func (s UserService) CreateUser(ctx context.Context, req modelUser.CreateUserReq
 		Password: string(hashPwdBytes),
 		Actor:    req.Actor,
 	}
+	if email := normalizeEmail(req.Email); len(email) > 0 {
+		insertUserArgs.Email = &email
+	}
 
+	var verificationToken string
 	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		err := s.userRepo.CreateUserTx(ctx, tx, insertUserArgs)
 		if err != nil {
 			return err
 		}
 
+		if insertUserArgs.Email != nil {
+			verificationToken, err = s.issueEmailVerificationTokenTx(ctx, tx, insertUserArgs.ID, *insertUserArgs.Email)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
 		return modelUser.CreateUserResp{}, err
 	}
 
+	if insertUserArgs.Email != nil {
+		// user is already created, verification mail can be resent by the user
+		err = s.sendEmailVerificationMail(ctx, insertUserArgs.Name, *insertUserArgs.Email, verificationToken)
+		if err != nil {
+			log.Error(ctx, "error send email verification mail", err)
+		}
+	}
+
 	return modelUser.CreateUserResp{
 		ID:       insertUserArgs.ID,
 		Name:     insertUserArgs.Name,
 		Username: insertUserArgs.Username,
 		Phone:    insertUserArgs.Phone,
+		Email:    insertUserArgs.Email,
 	}, nil
 }
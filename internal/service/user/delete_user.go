@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+	modelUser "golang-rest-api/internal/model/user"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DeleteUser soft delete user and revoke every session of the user
+func (s UserService) DeleteUser(ctx context.Context, req modelUser.DeleteUserReq) error {
+	return s.softDeleteUser(ctx, req.ID, req.Actor)
+}
+
+// DeleteAccount soft delete account of current user after confirming password
+func (s UserService) DeleteAccount(ctx context.Context, req modelUser.DeleteAccountReq) error {
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.confirmPassword(ctx, u, req.Password)
+	if err != nil {
+		return err
+	}
+
+	return s.softDeleteUser(ctx, u.ID, u.ID)
+}
+
+func (s UserService) RestoreUser(ctx context.Context, req modelUser.RestoreUserReq) error {
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.userRepo.RestoreUserTx(ctx, tx, req.ID, req.Actor)
+	})
+}
+
+func (s UserService) softDeleteUser(ctx context.Context, userID string, actor string) error {
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.userRepo.SoftDeleteUserTx(ctx, tx, userID, actor)
+		if err != nil {
+			return err
+		}
+
+		return s.sessionRepo.RevokeUserSessionsTx(ctx, tx, userID, "")
+	})
+}
@@ -0,0 +1,169 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/mailer"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SendEmailVerification issue new email verification token for user and mail it,
+// previously issued token can no longer be used
+func (s UserService) SendEmailVerification(ctx context.Context, userID string) error {
+	u, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if u.Email == nil {
+		return modelUser.ErrorEmailNotSet
+	}
+
+	if u.EmailVerifiedAt != nil {
+		return modelUser.ErrorEmailAlreadyVerified
+	}
+
+	var token string
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		token, err = s.issueEmailVerificationTokenTx(ctx, tx, u.ID, *u.Email)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmailVerificationMail(ctx, u.Name, *u.Email, token)
+}
+
+// ResendEmailVerification mail new verification link to user who can not log in because email is not verified,
+// unknown username, missing or verified email are not reported so the endpoint can not be used to enumerate users
+func (s UserService) ResendEmailVerification(ctx context.Context, req modelUser.ResendEmailVerificationReq) error {
+	err := s.limitMailRequest(ctx, req.ClientIP)
+	if err != nil {
+		return err
+	}
+
+	var u modelUser.User
+	// username can not contain @, so login with @ is always an email
+	if strings.Contains(req.Login, "@") {
+		u, err = s.userRepo.GetUserByEmail(ctx, normalizeEmail(req.Login))
+	} else {
+		u, err = s.userRepo.GetUserByUsername(ctx, req.Login)
+	}
+
+	if err != nil {
+		if err == modelUser.ErrorUserNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	if u.Email == nil || u.EmailVerifiedAt != nil {
+		log.Info(ctx, "email verification requested for user without unverified email")
+		return nil
+	}
+
+	// token is issued and mailed in background so response time does not reveal that the username exists
+	go s.resendEmailVerificationMail(context.WithoutCancel(ctx), u)
+
+	return nil
+}
+
+func (s UserService) resendEmailVerificationMail(ctx context.Context, u modelUser.User) {
+	// previous link is kept valid and not mailed again within cooldown, so repeated request can not flood the inbox
+	if s.mailCooldown > 0 {
+		recent, err := s.emailVerificationTokenRepo.HasEmailVerificationTokenCreatedSince(ctx, u.ID, *u.Email, s.timeNowFunc().Add(-s.mailCooldown))
+		if err != nil {
+			return
+		}
+
+		if recent {
+			log.Info(ctx, "email verification mail skipped within cooldown")
+			return
+		}
+	}
+
+	var token string
+	err := s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		token, err = s.issueEmailVerificationTokenTx(ctx, tx, u.ID, *u.Email)
+		return err
+	})
+	if err != nil {
+		log.Error(ctx, "error issue email verification token", err)
+		return
+	}
+
+	err = s.sendEmailVerificationMail(ctx, u.Name, *u.Email, token)
+	if err != nil {
+		log.Error(ctx, "error send email verification mail", err)
+	}
+}
+
+func (s UserService) VerifyEmail(ctx context.Context, req modelUser.VerifyEmailReq) error {
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		verificationToken, err := s.emailVerificationTokenRepo.ConsumeEmailVerificationTokenTx(ctx, tx, hashOneTimeToken(req.Token))
+		if err != nil {
+			return err
+		}
+
+		err = s.userRepo.MarkEmailVerifiedTx(ctx, tx, verificationToken.UserID, verificationToken.Email)
+		if err != nil {
+			return err
+		}
+
+		return s.emailVerificationTokenRepo.InvalidateUserEmailVerificationTokensTx(ctx, tx, verificationToken.UserID)
+	})
+}
+
+// issueEmailVerificationTokenTx store new verification token for email of user and return the raw token
+func (s UserService) issueEmailVerificationTokenTx(ctx context.Context, tx pgx.Tx, userID string, email string) (string, error) {
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		log.Error(ctx, "error generate email verification token", err)
+		return "", err
+	}
+
+	err = s.emailVerificationTokenRepo.InvalidateUserEmailVerificationTokensTx(ctx, tx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.emailVerificationTokenRepo.CreateEmailVerificationTokenTx(ctx, tx, modelUser.InsertEmailVerificationToken{
+		ID:        s.uuidGenerator(),
+		UserID:    userID,
+		Email:     email,
+		TokenHash: tokenHash,
+		ExpiresAt: s.timeNowFunc().Add(s.emailVerificationTokenExpireDuration),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s UserService) sendEmailVerificationMail(ctx context.Context, name string, email string, token string) error {
+	return s.mailer.Send(ctx, mailer.Mail{
+		To:      []string{email},
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to verify your email, the link expires in %s.\n\n%s?token=%s",
+			name,
+			s.emailVerificationTokenExpireDuration,
+			s.emailVerificationURL,
+			url.QueryEscape(token),
+		),
+	})
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/database"
+)
+
+func (s UserService) ListUsers(ctx context.Context, req modelUser.ListUsersReq) (modelUser.ListUsersResp, error) {
+	filter := modelUser.ListUsersFilter{
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+		Username:       req.Username,
+		Name:           req.Name,
+		Phone:          req.Phone,
+		CreatedFrom:    req.CreatedFrom,
+		CreatedTo:      req.CreatedTo,
+		IncludeDeleted: req.IncludeDeleted,
+		SortBy:         req.SortBy,
+		SortOrder:      req.SortOrder,
+	}
+
+	if req.UseCursor {
+		return s.listUsersByCursor(ctx, filter, req.Cursor)
+	}
+
+	users, total, err := s.userRepo.ListUsers(ctx, filter)
+	if err != nil {
+		return modelUser.ListUsersResp{}, err
+	}
+
+	return modelUser.ListUsersResp{
+		Users: toUserResps(users),
+		Total: total,
+	}, nil
+}
+
+func (s UserService) listUsersByCursor(ctx context.Context, filter modelUser.ListUsersFilter, cursorToken string) (modelUser.ListUsersResp, error) {
+	scope := database.CursorScope(
+		filter.SortOrder,
+		filter.Username,
+		filter.Name,
+		filter.Phone,
+		filter.CreatedFrom,
+		filter.CreatedTo,
+		filter.IncludeDeleted,
+	)
+
+	var cursor *database.Cursor
+	if len(cursorToken) > 0 {
+		c, err := database.DecodeCursor(cursorToken, scope)
+		if err != nil {
+			return modelUser.ListUsersResp{}, err
+		}
+		cursor = &c
+	}
+
+	limit := filter.Limit
+	// one more row is fetched to know whether there is next page
+	filter.Limit++
+
+	users, err := s.userRepo.ListUsersByCursor(ctx, filter, cursor)
+	if err != nil {
+		return modelUser.ListUsersResp{}, err
+	}
+
+	users, nextCursor := database.KeysetPage(users, limit, func(u modelUser.User) database.Cursor {
+		return database.Cursor{CreatedAt: u.CreatedAt, ID: u.ID, Scope: scope}
+	})
+
+	return modelUser.ListUsersResp{
+		Users:      toUserResps(users),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func toUserResps(users []modelUser.User) []modelUser.UserResp {
+	res := make([]modelUser.UserResp, 0, len(users))
+	for _, u := range users {
+		res = append(res, modelUser.UserResp{
+			ID:            u.ID,
+			Name:          u.Name,
+			Username:      u.Username,
+			Phone:         u.Phone,
+			Email:         u.Email,
+			EmailVerified: u.EmailVerifiedAt != nil,
+			CreatedAt:     u.CreatedAt,
+			DeletedAt:     u.DeletedAt,
+		})
+	}
+
+	return res
+}
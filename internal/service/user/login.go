@@ -4,22 +4,104 @@ import (
 	"context"
 	modelUser "golang-rest-api/internal/model/user"
 	"golang-rest-api/pkg/jwt"
+	"golang-rest-api/pkg/log"
+	"slices"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s UserService) UserLogin(ctx context.Context, req modelUser.UserLoginReq) (modelUser.UserLoginResp, error) {
-	u, err := s.userRepo.GetUserByUsername(ctx, req.Username)
+	audience := []string{}
+	if len(req.Audience) > 0 {
+		if !slices.Contains(s.allowedAudiences, req.Audience) {
+			return modelUser.UserLoginResp{}, modelUser.ErrorInvalidAudience
+		}
+
+		audience = append(audience, req.Audience)
+	}
+
+	err := s.checkLoginLockout(ctx, req)
 	if err != nil {
 		return modelUser.UserLoginResp{}, err
 	}
 
+	u, err := s.userRepo.GetUserByUsername(ctx, req.Username)
+	if err != nil && err != modelUser.ErrorUserNotFound {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	if err == modelUser.ErrorUserNotFound {
+		// password is still compared so response time does not reveal that user not exist
+		s.compareDummyPassword(ctx, req.Password, nil)
+		log.Error(ctx, "login failed: user not found", err, log.LogField{Key: "username", Value: req.Username})
+
+		return modelUser.UserLoginResp{}, s.loginFailed(ctx, req)
+	}
+
 	passMatch := s.crypter.IsPWAndHashPWMatch(ctx, []byte(req.Password), []byte(u.Password))
 	if !passMatch {
-		return modelUser.UserLoginResp{}, modelUser.ErrorLoginErrorWrongPassword
+		s.compareDummyPassword(ctx, req.Password, []byte(u.Password))
+		log.Error(ctx, "login failed: wrong password", modelUser.ErrorInvalidCredentials, log.LogField{Key: "username", Value: req.Username})
+
+		return modelUser.UserLoginResp{}, s.loginFailed(ctx, req)
+	}
+
+	err = s.resetLoginFailure(ctx, req)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	if s.crypter.NeedsRehash([]byte(u.Password)) {
+		s.rehashPassword(ctx, u.ID, req.Password)
+	}
+
+	// user without email can not verify it, so only user with unverified email is rejected
+	if s.emailVerificationRequired && u.Email != nil && u.EmailVerifiedAt == nil {
+		return modelUser.UserLoginResp{}, modelUser.ErrorEmailNotVerified
+	}
+
+	if u.MFAEnabledAt != nil {
+		mfaToken, err := s.jwtGenerator.GenerateMFAPendingJWT(ctx, u.ID, audience...)
+		if err != nil {
+			return modelUser.UserLoginResp{}, err
+		}
+
+		return modelUser.UserLoginResp{
+			MFARequired:       true,
+			MFAToken:          mfaToken.Token,
+			MFATokenExpiresAt: mfaToken.ExpiresAt,
+		}, nil
+	}
+
+	return s.issueLoginToken(ctx, u, audience, []string{jwt.AuthMethodPassword})
+}
+
+// issueLoginToken generate token of user authenticated using authMethods and start new session
+func (s UserService) issueLoginToken(ctx context.Context, u modelUser.User, audience []string, authMethods []string) (modelUser.UserLoginResp, error) {
+	authorization, err := s.roleRepo.GetUserAuthorization(ctx, u.ID)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
 	}
 
-	jwtToken, err := s.jwtGenerator.GenerateJWT(ctx, jwt.User{
-		ID:       u.ID,
-		Username: u.Username,
+	jwtToken, err := s.jwtGenerator.GenerateJWTForAudience(ctx, jwt.User{
+		ID:          u.ID,
+		Username:    u.Username,
+		Roles:       authorization.Roles,
+		Permissions: authorization.Permissions,
+		AuthMethods: authMethods,
+	}, audience...)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.sessionRepo.CreateSessionTx(ctx, tx, modelUser.InsertSession{
+			ID:        jwtToken.RefreshTokenID,
+			FamilyID:  s.uuidGenerator(),
+			UserID:    u.ID,
+			ExpiresAt: jwtToken.RefreshTokenExpiresAt,
+		})
 	})
 	if err != nil {
 		return modelUser.UserLoginResp{}, err
@@ -32,3 +114,60 @@ func (s UserService) UserLogin(ctx context.Context, req modelUser.UserLoginReq)
 		RefreshTokenExpiresAt: jwtToken.RefreshTokenExpiresAt,
 	}, nil
 }
+
+// loginFailed record failed login and return ErrorInvalidCredentials,
+// unknown username and wrong password get the same error so username can not be enumerated
+func (s UserService) loginFailed(ctx context.Context, req modelUser.UserLoginReq) error {
+	err := s.recordLoginFailure(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return modelUser.ErrorInvalidCredentials
+}
+
+// rehashPassword replace stored hash generated using outdated algorithm or parameters,
+// failure is only logged because the password has been verified
+func (s UserService) rehashPassword(ctx context.Context, userID string, password string) {
+	hashPwdBytes, err := s.crypter.GenerateHash(ctx, password)
+	if err != nil {
+		return
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.userRepo.UpdateUserPasswordTx(ctx, tx, modelUser.UpdateUserPassword{
+			ID:       userID,
+			Password: string(hashPwdBytes),
+			Actor:    userID,
+		})
+	})
+	if err != nil {
+		log.Error(ctx, "error rehash password", err, log.LogField{Key: "user_id", Value: userID})
+	}
+}
+
+// dummyPasswordHash hashes generated once, current by the crypter so it has the same cost as stored password
+// and legacy by the legacy crypter so it has the same cost as password which is not rehashed yet
+type dummyPasswordHash struct {
+	once    sync.Once
+	current []byte
+	legacy  []byte
+}
+
+// compareDummyPassword pad failed login so it always compare one password of current and one of legacy cost,
+// response time then does not reveal whether user exist or how its password is hashed,
+// storedHash is nil when user does not exist
+func (s UserService) compareDummyPassword(ctx context.Context, password string, storedHash []byte) {
+	s.dummyPasswordHash.once.Do(func() {
+		s.dummyPasswordHash.current, _ = s.crypter.GenerateHash(ctx, s.uuidGenerator())
+		s.dummyPasswordHash.legacy, _ = s.legacyCrypter.GenerateHash(ctx, s.uuidGenerator())
+	})
+
+	if storedHash == nil || !s.crypter.NeedsRehash(storedHash) {
+		s.legacyCrypter.IsPWAndHashPWMatch(ctx, []byte(password), s.dummyPasswordHash.legacy)
+	}
+
+	if storedHash == nil || s.crypter.NeedsRehash(storedHash) {
+		s.crypter.IsPWAndHashPWMatch(ctx, []byte(password), s.dummyPasswordHash.current)
+	}
+}
@@ -0,0 +1,205 @@
+package user
+
+import (
+	"context"
+	modelUser "golang-rest-api/internal/model/user"
+	"time"
+)
+
+const (
+	lockoutKeyPrefixUsername   = "username:"
+	lockoutKeyPrefixUsernameIP = "username_ip:"
+	lockoutKeyPrefixIP         = "ip:"
+	lockoutKeyPrefixMFA        = "mfa:"
+	// lockoutKeyPrefixPasswordConfirm password asked again from user who is already logged in
+	lockoutKeyPrefixPasswordConfirm = "password_confirm:"
+	// lockoutKeyPrefixMailIP mail requested without login from client ip
+	lockoutKeyPrefixMailIP = "mail_ip:"
+)
+
+// checkLoginLockout return ErrorAccountLocked when username from client ip or the client ip of login request is locked
+func (s UserService) checkLoginLockout(ctx context.Context, req modelUser.UserLoginReq) error {
+	if s.loginAttemptStore == nil {
+		return nil
+	}
+
+	now := s.timeNowFunc()
+	for _, key := range loginLockoutKeys(req) {
+		attempt, err := s.loginAttemptStore.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if attempt.IsLocked(now) {
+			return modelUser.ErrorAccountLocked
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure count failed login toward username from client ip and client ip lockout,
+// then delay the failure by username delay so only wrong credentials are slowed down, never the owner's valid login
+func (s UserService) recordLoginFailure(ctx context.Context, req modelUser.UserLoginReq) error {
+	if s.loginAttemptStore == nil {
+		return nil
+	}
+
+	_, err := s.loginAttemptStore.RecordFailure(ctx, usernameIPLockoutKey(req), s.usernameLockoutPolicy)
+	if err != nil {
+		return err
+	}
+
+	usernameAttempt, err := s.loginAttemptStore.RecordFailure(ctx, lockoutKeyPrefixUsername+req.Username, s.usernameDelayPolicy)
+	if err != nil {
+		return err
+	}
+
+	if len(req.ClientIP) > 0 {
+		_, err = s.loginAttemptStore.RecordFailure(ctx, lockoutKeyPrefixIP+req.ClientIP, s.ipLockoutPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return delayLogin(ctx, s.usernameDelayPolicy.LockDuration(usernameAttempt.Failures))
+}
+
+// resetLoginFailure forget failed login of username, client ip is not reset
+// so attacker can not clear it by logging in to own account
+func (s UserService) resetLoginFailure(ctx context.Context, req modelUser.UserLoginReq) error {
+	if s.loginAttemptStore == nil {
+		return nil
+	}
+
+	err := s.loginAttemptStore.Reset(ctx, usernameIPLockoutKey(req))
+	if err != nil {
+		return err
+	}
+
+	return s.loginAttemptStore.Reset(ctx, lockoutKeyPrefixUsername+req.Username)
+}
+
+func loginLockoutKeys(req modelUser.UserLoginReq) []string {
+	keys := []string{usernameIPLockoutKey(req)}
+	if len(req.ClientIP) > 0 {
+		keys = append(keys, lockoutKeyPrefixIP+req.ClientIP)
+	}
+
+	return keys
+}
+
+// usernameIPLockoutKey client ip is put last because it can not contain the separator unlike username
+func usernameIPLockoutKey(req modelUser.UserLoginReq) string {
+	return lockoutKeyPrefixUsernameIP + req.Username + "|" + req.ClientIP
+}
+
+// delayLogin wait for delay or until request is cancelled, used to slow down failed login
+func delayLogin(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkMFALockout return ErrorAccountLocked when MFA code of user is locked,
+// MFA failure is counted separately because password of the user is already verified
+func (s UserService) checkMFALockout(ctx context.Context, userID string) error {
+	if s.loginAttemptStore == nil {
+		return nil
+	}
+
+	attempt, err := s.loginAttemptStore.Get(ctx, lockoutKeyPrefixMFA+userID)
+	if err != nil {
+		return err
+	}
+
+	if attempt.IsLocked(s.timeNowFunc()) {
+		return modelUser.ErrorAccountLocked
+	}
+
+	return nil
+}
+
+// mfaFailed record failed MFA code and return ErrorMFACodeInvalid
+func (s UserService) mfaFailed(ctx context.Context, userID string) error {
+	if s.loginAttemptStore != nil {
+		_, err := s.loginAttemptStore.RecordFailure(ctx, lockoutKeyPrefixMFA+userID, s.usernameLockoutPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return modelUser.ErrorMFACodeInvalid
+}
+
+func (s UserService) resetMFAFailure(ctx context.Context, userID string) error {
+	if s.loginAttemptStore == nil {
+		return nil
+	}
+
+	return s.loginAttemptStore.Reset(ctx, lockoutKeyPrefixMFA+userID)
+}
+
+// confirmPassword compare password of logged in user, e.g. before changing it, and return ErrorCurrentPasswordNotMatch when it does not match,
+// failures are counted so stolen session can not be used to guess the password
+func (s UserService) confirmPassword(ctx context.Context, u modelUser.User, password string) error {
+	key := lockoutKeyPrefixPasswordConfirm + u.ID
+	if s.loginAttemptStore != nil {
+		attempt, err := s.loginAttemptStore.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if attempt.IsLocked(s.timeNowFunc()) {
+			return modelUser.ErrorAccountLocked
+		}
+	}
+
+	passMatch := s.crypter.IsPWAndHashPWMatch(ctx, []byte(password), []byte(u.Password))
+	if !passMatch {
+		if s.loginAttemptStore != nil {
+			_, err := s.loginAttemptStore.RecordFailure(ctx, key, s.usernameLockoutPolicy)
+			if err != nil {
+				return err
+			}
+		}
+
+		return modelUser.ErrorCurrentPasswordNotMatch
+	}
+
+	if s.loginAttemptStore == nil {
+		return nil
+	}
+
+	return s.loginAttemptStore.Reset(ctx, key)
+}
+
+// limitMailRequest count mail requested without login from client ip and return ErrorTooManyRequests once the ip is limited
+func (s UserService) limitMailRequest(ctx context.Context, clientIP string) error {
+	if s.loginAttemptStore == nil || len(clientIP) == 0 {
+		return nil
+	}
+
+	key := lockoutKeyPrefixMailIP + clientIP
+	attempt, err := s.loginAttemptStore.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if attempt.IsLocked(s.timeNowFunc()) {
+		return modelUser.ErrorTooManyRequests
+	}
+
+	_, err = s.loginAttemptStore.RecordFailure(ctx, key, s.mailIPPolicy)
+	return err
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/jwt"
+)
+
+func (s UserService) UserLogout(ctx context.Context, req modelUser.UserLogoutReq) error {
+	err := s.jwtRevocationStore.Revoke(ctx, req.AccessTokenID, req.AccessTokenExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if len(req.RefreshToken) == 0 {
+		return nil
+	}
+
+	// refresh token is optional on logout, invalid one is ignored
+	// since the access token is already revoked
+	claims, err := s.jwtParser.ParseAndValidateWithTokenType(ctx, req.RefreshToken, jwt.JWTTokenTypeRefresh)
+	if err != nil {
+		return nil
+	}
+
+	session, err := s.sessionRepo.GetSessionByID(ctx, claims.ID)
+	if err != nil {
+		if err == modelUser.ErrorSessionNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	return s.sessionRepo.RevokeSessionFamily(ctx, session.FamilyID)
+}
@@ -0,0 +1,317 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	modelUser "golang-rest-api/internal/model/user"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/jwt"
+	"golang-rest-api/pkg/log"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollMFA generate new TOTP secret for user after confirming password, MFA is only enabled after the secret is confirmed,
+// enrolling again replace the pending secret
+func (s UserService) EnrollMFA(ctx context.Context, req modelUser.EnrollMFAReq) (modelUser.EnrollMFAResp, error) {
+	if s.totp == nil || s.mfaEncrypter == nil {
+		return modelUser.EnrollMFAResp{}, modelUser.ErrorMFAUnavailable
+	}
+
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return modelUser.EnrollMFAResp{}, err
+	}
+
+	err = s.confirmPassword(ctx, u, req.Password)
+	if err != nil {
+		return modelUser.EnrollMFAResp{}, err
+	}
+
+	if u.MFAEnabledAt != nil {
+		return modelUser.EnrollMFAResp{}, modelUser.ErrorMFAAlreadyEnabled
+	}
+
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		log.Error(ctx, "error generate mfa secret", err)
+		return modelUser.EnrollMFAResp{}, err
+	}
+
+	encryptedSecret, err := s.mfaEncrypter.Encrypt(ctx, secret)
+	if err != nil {
+		return modelUser.EnrollMFAResp{}, err
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.userRepo.SetUserMFASecretTx(ctx, tx, u.ID, encryptedSecret)
+	})
+	if err != nil {
+		return modelUser.EnrollMFAResp{}, err
+	}
+
+	return modelUser.EnrollMFAResp{
+		Secret:     secret,
+		OTPAuthURI: s.totp.KeyURI(u.Username, secret),
+	}, nil
+}
+
+// ConfirmMFA enable MFA after password and code of enrolled secret are verified and return new recovery codes,
+// every other session of the user is revoked
+func (s UserService) ConfirmMFA(ctx context.Context, req modelUser.ConfirmMFAReq) (modelUser.ConfirmMFAResp, error) {
+	if s.totp == nil || s.mfaEncrypter == nil {
+		return modelUser.ConfirmMFAResp{}, modelUser.ErrorMFAUnavailable
+	}
+
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	err = s.confirmPassword(ctx, u, req.Password)
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	err = s.checkMFALockout(ctx, u.ID)
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	mfa, err := s.userRepo.GetUserMFA(ctx, u.ID)
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	if mfa.EnabledAt != nil {
+		return modelUser.ConfirmMFAResp{}, modelUser.ErrorMFAAlreadyEnabled
+	}
+
+	if mfa.Secret == nil {
+		return modelUser.ConfirmMFAResp{}, modelUser.ErrorMFANotEnrolled
+	}
+
+	secret, err := s.mfaEncrypter.Decrypt(ctx, *mfa.Secret)
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	counter, ok := s.totp.Validate(secret, req.Code)
+	if !ok {
+		return modelUser.ConfirmMFAResp{}, s.mfaFailed(ctx, u.ID)
+	}
+
+	codes, insertCodes, err := s.generateRecoveryCodes(u.ID)
+	if err != nil {
+		log.Error(ctx, "error generate recovery codes", err)
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	currentFamilyID := s.currentSessionFamilyID(ctx, u.ID, req.RefreshToken)
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.userRepo.EnableUserMFATx(ctx, tx, u.ID, counter)
+		if err != nil {
+			return err
+		}
+
+		err = s.recoveryCodeRepo.DeleteUserRecoveryCodesTx(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.recoveryCodeRepo.CreateRecoveryCodesTx(ctx, tx, insertCodes)
+		if err != nil {
+			return err
+		}
+
+		return s.sessionRepo.RevokeUserSessionsTx(ctx, tx, u.ID, currentFamilyID)
+	})
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	err = s.resetMFAFailure(ctx, u.ID)
+	if err != nil {
+		return modelUser.ConfirmMFAResp{}, err
+	}
+
+	return modelUser.ConfirmMFAResp{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableMFA disable MFA after confirming password and MFA code, recovery codes are deleted
+// and every other session of the user is revoked
+func (s UserService) DisableMFA(ctx context.Context, req modelUser.DisableMFAReq) error {
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.confirmPassword(ctx, u, req.Password)
+	if err != nil {
+		return err
+	}
+
+	if u.MFAEnabledAt == nil {
+		return modelUser.ErrorMFANotEnabled
+	}
+
+	err = s.checkMFALockout(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+
+	currentFamilyID := s.currentSessionFamilyID(ctx, u.ID, req.RefreshToken)
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.verifyMFACodeTx(ctx, tx, u.ID, req.Code)
+		if err != nil {
+			return err
+		}
+
+		err = s.userRepo.DisableUserMFATx(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.recoveryCodeRepo.DeleteUserRecoveryCodesTx(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.sessionRepo.RevokeUserSessionsTx(ctx, tx, u.ID, currentFamilyID)
+	})
+	if err == modelUser.ErrorMFACodeInvalid {
+		return s.mfaFailed(ctx, u.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.resetMFAFailure(ctx, u.ID)
+}
+
+// LoginMFA exchange mfa token returned by UserLogin with access token after MFA code is verified,
+// the mfa token can only be exchanged once
+func (s UserService) LoginMFA(ctx context.Context, req modelUser.LoginMFAReq) (modelUser.UserLoginResp, error) {
+	claims, err := s.jwtParser.ParseAndValidateWithTokenType(ctx, req.MFAToken, jwt.JWTTokenTypeMFAPending)
+	if err != nil {
+		return modelUser.UserLoginResp{}, pkgErr.NewCustomErrWithOriginalErr(modelUser.ErrorMFATokenInvalid, err)
+	}
+
+	revoked, err := s.jwtRevocationStore.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	if revoked {
+		return modelUser.UserLoginResp{}, modelUser.ErrorMFATokenInvalid
+	}
+
+	err = s.checkMFALockout(ctx, claims.Subject)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	u, err := s.userRepo.GetUserByID(ctx, claims.Subject)
+	if err != nil {
+		if err == modelUser.ErrorUserNotFound {
+			return modelUser.UserLoginResp{}, modelUser.ErrorMFATokenInvalid
+		}
+
+		return modelUser.UserLoginResp{}, err
+	}
+
+	if u.MFAEnabledAt == nil {
+		return modelUser.UserLoginResp{}, modelUser.ErrorMFATokenInvalid
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.verifyMFACodeTx(ctx, tx, u.ID, req.Code)
+	})
+	if err == modelUser.ErrorMFACodeInvalid {
+		log.Error(ctx, "login failed: invalid mfa code", err, log.LogField{Key: "user_id", Value: u.ID})
+		return modelUser.UserLoginResp{}, s.mfaFailed(ctx, u.ID)
+	}
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	err = s.jwtRevocationStore.Revoke(ctx, claims.ID, claims.ExpireAt.Time)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	err = s.resetMFAFailure(ctx, u.ID)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	return s.issueLoginToken(ctx, u, claims.Audience, []string{jwt.AuthMethodPassword, jwt.AuthMethodOTP})
+}
+
+// verifyMFACodeTx accept TOTP code which time step is later than the last used one or unused recovery code,
+// return ErrorMFACodeInvalid otherwise
+func (s UserService) verifyMFACodeTx(ctx context.Context, tx pgx.Tx, userID string, code string) error {
+	if s.totp == nil || s.mfaEncrypter == nil {
+		return modelUser.ErrorMFAUnavailable
+	}
+
+	mfa, err := s.userRepo.GetUserMFA(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if mfa.EnabledAt == nil || mfa.Secret == nil {
+		return modelUser.ErrorMFANotEnabled
+	}
+
+	secret, err := s.mfaEncrypter.Decrypt(ctx, *mfa.Secret)
+	if err != nil {
+		return err
+	}
+
+	counter, ok := s.totp.Validate(secret, code)
+	if ok {
+		return s.userRepo.UseUserMFACounterTx(ctx, tx, userID, counter)
+	}
+
+	return s.recoveryCodeRepo.ConsumeRecoveryCodeTx(ctx, tx, userID, hashOneTimeToken(normalizeRecoveryCode(code)))
+}
+
+// generateRecoveryCodes return recovery codes shown to user and their hash to be stored
+func (s UserService) generateRecoveryCodes(userID string) ([]string, []modelUser.InsertRecoveryCode, error) {
+	codes := make([]string, 0, modelUser.RecoveryCodeCount)
+	insertCodes := make([]modelUser.InsertRecoveryCode, 0, modelUser.RecoveryCodeCount)
+	for i := 0; i < modelUser.RecoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		code := raw[:8] + "-" + raw[8:]
+
+		codes = append(codes, code)
+		insertCodes = append(insertCodes, modelUser.InsertRecoveryCode{
+			ID:       s.uuidGenerator(),
+			UserID:   userID,
+			CodeHash: hashOneTimeToken(normalizeRecoveryCode(code)),
+		})
+	}
+
+	return codes, insertCodes, nil
+}
+
+// normalizeRecoveryCode ignore case, separator and whitespace typed by user
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// generateOneTimeToken return random url safe token sent to user and its hash,
+// only the hash is stored so leaked database can not be used to redeem token
+func generateOneTimeToken() (string, string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashOneTimeToken(token), nil
+}
+
+func hashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,139 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/log"
+	"golang-rest-api/pkg/mailer"
+	"net/url"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ForgotPassword issue password reset token and mail reset link to user,
+// unknown username is not reported so the endpoint can not be used to enumerate users
+func (s UserService) ForgotPassword(ctx context.Context, req modelUser.ForgotPasswordReq) error {
+	err := s.limitMailRequest(ctx, req.ClientIP)
+	if err != nil {
+		return err
+	}
+
+	u, err := s.userRepo.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		if err == modelUser.ErrorUserNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	if u.Email == nil {
+		log.Info(ctx, "password reset requested for user without email")
+		return nil
+	}
+
+	// token is issued and mailed in background so response time does not reveal that the username exists
+	go s.sendPasswordResetMail(context.WithoutCancel(ctx), u)
+
+	return nil
+}
+
+func (s UserService) sendPasswordResetMail(ctx context.Context, u modelUser.User) {
+	// previous link is kept valid and not mailed again within cooldown, so repeated request can not flood the inbox
+	if s.mailCooldown > 0 {
+		recent, err := s.passwordResetTokenRepo.HasPasswordResetTokenCreatedSince(ctx, u.ID, s.timeNowFunc().Add(-s.mailCooldown))
+		if err != nil {
+			return
+		}
+
+		if recent {
+			log.Info(ctx, "password reset mail skipped within cooldown")
+			return
+		}
+	}
+
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		log.Error(ctx, "error generate password reset token", err)
+		return
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		// only the latest requested token can be used
+		err := s.passwordResetTokenRepo.InvalidateUserPasswordResetTokensTx(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.passwordResetTokenRepo.CreatePasswordResetTokenTx(ctx, tx, modelUser.InsertPasswordResetToken{
+			ID:        s.uuidGenerator(),
+			UserID:    u.ID,
+			TokenHash: tokenHash,
+			ExpiresAt: s.timeNowFunc().Add(s.passwordResetTokenExpireDuration),
+		})
+	})
+	if err != nil {
+		log.Error(ctx, "error issue password reset token", err)
+		return
+	}
+
+	err = s.mailer.Send(ctx, mailer.Mail{
+		To:      []string{*u.Email},
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to reset your password, the link expires in %s.\n\n%s?token=%s\n\nIgnore this mail if you did not request password reset.",
+			u.Name,
+			s.passwordResetTokenExpireDuration,
+			s.passwordResetURL,
+			url.QueryEscape(token),
+		),
+	})
+	if err != nil {
+		log.Error(ctx, "error send password reset mail", err)
+	}
+}
+
+// ResetPassword set new password using password reset token,
+// token can only be used once and every session of the user is revoked
+func (s UserService) ResetPassword(ctx context.Context, req modelUser.ResetPasswordReq) error {
+	return s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		userID, err := s.passwordResetTokenRepo.ConsumePasswordResetTokenTx(ctx, tx, hashOneTimeToken(req.Token))
+		if err != nil {
+			return err
+		}
+
+		u, err := s.userRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		// token is kept unused when password is rejected so user can try another password
+		err = s.passwordPolicy.Check(ctx, req.NewPassword, u.Username)
+		if err != nil {
+			return err
+		}
+
+		// hashed only after token and password are accepted, so invalid request does not cost a hash
+		hashPwdBytes, err := s.crypter.GenerateHash(ctx, req.NewPassword)
+		if err != nil {
+			return err
+		}
+
+		err = s.userRepo.UpdateUserPasswordTx(ctx, tx, modelUser.UpdateUserPassword{
+			ID:       userID,
+			Password: string(hashPwdBytes),
+			Actor:    userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.passwordResetTokenRepo.InvalidateUserPasswordResetTokensTx(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		return s.sessionRepo.RevokeUserSessionsTx(ctx, tx, userID, "")
+	})
+}
@@ -3,6 +3,9 @@ package user
 import (
 	"context"
 	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/log"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s UserService) UserProfile(ctx context.Context, userID string) (modelUser.UserProfileResp, error) {
@@ -12,9 +15,94 @@ func (s UserService) UserProfile(ctx context.Context, userID string) (modelUser.
 	}
 
 	return modelUser.UserProfileResp{
-		ID:       u.ID,
-		Name:     u.Name,
-		Username: u.Username,
-		Phone:    u.Phone,
+		ID:            u.ID,
+		Name:          u.Name,
+		Username:      u.Username,
+		Phone:         u.Phone,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerifiedAt != nil,
+		MFAEnabled:    u.MFAEnabledAt != nil,
+	}, nil
+}
+
+// UserDetail return user by id including deleted one with audit fields, used by admin
+func (s UserService) UserDetail(ctx context.Context, userID string) (modelUser.UserDetailResp, error) {
+	u, err := s.userRepo.GetUserDetailByID(ctx, userID)
+	if err != nil {
+		return modelUser.UserDetailResp{}, err
+	}
+
+	return modelUser.UserDetailResp{
+		UserProfileResp: modelUser.UserProfileResp{
+			ID:            u.ID,
+			Name:          u.Name,
+			Username:      u.Username,
+			Phone:         u.Phone,
+			Email:         u.Email,
+			EmailVerified: u.EmailVerifiedAt != nil,
+			MFAEnabled:    u.MFAEnabledAt != nil,
+		},
+		CreatedAt: u.CreatedAt,
+		CreatedBy: u.CreatedBy,
+		UpdatedAt: u.UpdatedAt,
+		UpdatedBy: u.UpdatedBy,
+		DeletedAt: u.DeletedAt,
+		DeletedBy: u.DeletedBy,
 	}, nil
 }
+
+// UpdateUserProfile update profile of user, verification mail is sent when email is changed
+func (s UserService) UpdateUserProfile(ctx context.Context, req modelUser.UpdateProfileReq) (modelUser.UserProfileResp, error) {
+	emailChanged := false
+	if req.Email != nil {
+		email := normalizeEmail(*req.Email)
+		req.Email = &email
+
+		u, err := s.userRepo.GetUserByID(ctx, req.ID)
+		if err != nil {
+			return modelUser.UserProfileResp{}, err
+		}
+
+		emailChanged = u.Email == nil || *u.Email != email
+	}
+
+	var verificationToken string
+	err := s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.userRepo.UpdateUserTx(ctx, tx, modelUser.UpdateUser{
+			ID:    req.ID,
+			Name:  req.Name,
+			Phone: req.Phone,
+			Email: req.Email,
+			Actor: req.Actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		if emailChanged {
+			verificationToken, err = s.issueEmailVerificationTokenTx(ctx, tx, req.ID, *req.Email)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return modelUser.UserProfileResp{}, err
+	}
+
+	resp, err := s.UserProfile(ctx, req.ID)
+	if err != nil {
+		return modelUser.UserProfileResp{}, err
+	}
+
+	if emailChanged {
+		err = s.sendEmailVerificationMail(ctx, resp.Name, *req.Email, verificationToken)
+		if err != nil {
+			log.Error(ctx, "error send email verification mail", err)
+		}
+	}
+
+	return resp, nil
+}
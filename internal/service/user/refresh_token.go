@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	modelUser "golang-rest-api/internal/model/user"
+	"golang-rest-api/pkg/jwt"
+	"golang-rest-api/pkg/log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s UserService) UserRefreshToken(ctx context.Context, req modelUser.RefreshTokenReq) (modelUser.UserLoginResp, error) {
+	claims, err := s.jwtParser.ParseAndValidateWithTokenType(ctx, req.RefreshToken, jwt.JWTTokenTypeRefresh)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	session, err := s.sessionRepo.GetSessionByID(ctx, claims.ID)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	if session.RevokedAt != nil {
+		return modelUser.UserLoginResp{}, modelUser.ErrorSessionRevoked
+	}
+
+	if session.RotatedAt != nil {
+		return modelUser.UserLoginResp{}, s.revokeReusedSession(ctx, session)
+	}
+
+	u, err := s.userRepo.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	authorization, err := s.roleRepo.GetUserAuthorization(ctx, u.ID)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	// new token is issued for the same client and authentication methods as the refresh token
+	jwtToken, err := s.jwtGenerator.GenerateJWTForAudience(ctx, jwt.User{
+		ID:          u.ID,
+		Username:    u.Username,
+		Roles:       authorization.Roles,
+		Permissions: authorization.Permissions,
+		AuthMethods: claims.AuthMethods,
+	}, claims.Audience...)
+	if err != nil {
+		return modelUser.UserLoginResp{}, err
+	}
+
+	err = s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.sessionRepo.RotateSessionTx(ctx, tx, session.ID, jwtToken.RefreshTokenID)
+		if err != nil {
+			return err
+		}
+
+		return s.sessionRepo.CreateSessionTx(ctx, tx, modelUser.InsertSession{
+			ID:        jwtToken.RefreshTokenID,
+			FamilyID:  session.FamilyID,
+			UserID:    u.ID,
+			ExpiresAt: jwtToken.RefreshTokenExpiresAt,
+		})
+	})
+	if err != nil {
+		if err == modelUser.ErrorRefreshTokenReused {
+			return modelUser.UserLoginResp{}, s.revokeReusedSession(ctx, session)
+		}
+
+		return modelUser.UserLoginResp{}, err
+	}
+
+	return modelUser.UserLoginResp{
+		AccessToken:           jwtToken.AccessToken,
+		ExpiresAt:             jwtToken.ExpiresAt,
+		RefreshToken:          jwtToken.RefreshToken,
+		RefreshTokenExpiresAt: jwtToken.RefreshTokenExpiresAt,
+	}, nil
+}
+
+// revokeReusedSession revoke every session in the family of a refresh token
+// that has already been rotated, because it is likely to be stolen
+func (s UserService) revokeReusedSession(ctx context.Context, session modelUser.Session) error {
+	log.Error(ctx, "refresh token reuse detected, revoking session family", modelUser.ErrorRefreshTokenReused)
+
+	err := s.sessionRepo.RevokeSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	return modelUser.ErrorRefreshTokenReused
+}
@@ -3,10 +3,16 @@ package user
 import (
 	"context"
 	modelUser "golang-rest-api/internal/model/user"
+	repoRole "golang-rest-api/internal/repository/role"
 	repoUser "golang-rest-api/internal/repository/user"
 	"golang-rest-api/pkg/crypter"
 	"golang-rest-api/pkg/database"
 	"golang-rest-api/pkg/jwt"
+	"golang-rest-api/pkg/lockout"
+	"golang-rest-api/pkg/mailer"
+	passwordpolicy "golang-rest-api/pkg/password_policy"
+	"golang-rest-api/pkg/totp"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,7 +20,25 @@ import (
 type IUserService interface {
 	CreateUser(ctx context.Context, req modelUser.CreateUserReq) (modelUser.CreateUserResp, error)
 	UserLogin(ctx context.Context, req modelUser.UserLoginReq) (modelUser.UserLoginResp, error)
+	UserRefreshToken(ctx context.Context, req modelUser.RefreshTokenReq) (modelUser.UserLoginResp, error)
+	UserLogout(ctx context.Context, req modelUser.UserLogoutReq) error
 	UserProfile(ctx context.Context, userID string) (modelUser.UserProfileResp, error)
+	UserDetail(ctx context.Context, userID string) (modelUser.UserDetailResp, error)
+	UpdateUserProfile(ctx context.Context, req modelUser.UpdateProfileReq) (modelUser.UserProfileResp, error)
+	ChangePassword(ctx context.Context, req modelUser.ChangePasswordReq) error
+	ForgotPassword(ctx context.Context, req modelUser.ForgotPasswordReq) error
+	ResetPassword(ctx context.Context, req modelUser.ResetPasswordReq) error
+	SendEmailVerification(ctx context.Context, userID string) error
+	ResendEmailVerification(ctx context.Context, req modelUser.ResendEmailVerificationReq) error
+	VerifyEmail(ctx context.Context, req modelUser.VerifyEmailReq) error
+	DeleteUser(ctx context.Context, req modelUser.DeleteUserReq) error
+	DeleteAccount(ctx context.Context, req modelUser.DeleteAccountReq) error
+	RestoreUser(ctx context.Context, req modelUser.RestoreUserReq) error
+	ListUsers(ctx context.Context, req modelUser.ListUsersReq) (modelUser.ListUsersResp, error)
+	EnrollMFA(ctx context.Context, req modelUser.EnrollMFAReq) (modelUser.EnrollMFAResp, error)
+	ConfirmMFA(ctx context.Context, req modelUser.ConfirmMFAReq) (modelUser.ConfirmMFAResp, error)
+	DisableMFA(ctx context.Context, req modelUser.DisableMFAReq) error
+	LoginMFA(ctx context.Context, req modelUser.LoginMFAReq) (modelUser.UserLoginResp, error)
 }
 
 type UserServiceOption func(*UserService)
@@ -25,6 +49,56 @@ func WithUserRepo(userRepo repoUser.IUserRepo) UserServiceOption {
 	}
 }
 
+func WithSessionRepo(sessionRepo repoUser.ISessionRepo) UserServiceOption {
+	return func(us *UserService) {
+		us.sessionRepo = sessionRepo
+	}
+}
+
+func WithRoleRepo(roleRepo repoRole.IRoleRepo) UserServiceOption {
+	return func(us *UserService) {
+		us.roleRepo = roleRepo
+	}
+}
+
+func WithPasswordResetTokenRepo(passwordResetTokenRepo repoUser.IPasswordResetTokenRepo) UserServiceOption {
+	return func(us *UserService) {
+		us.passwordResetTokenRepo = passwordResetTokenRepo
+	}
+}
+
+func WithEmailVerificationTokenRepo(emailVerificationTokenRepo repoUser.IEmailVerificationTokenRepo) UserServiceOption {
+	return func(us *UserService) {
+		us.emailVerificationTokenRepo = emailVerificationTokenRepo
+	}
+}
+
+func WithRecoveryCodeRepo(recoveryCodeRepo repoUser.IRecoveryCodeRepo) UserServiceOption {
+	return func(us *UserService) {
+		us.recoveryCodeRepo = recoveryCodeRepo
+	}
+}
+
+func WithCrypter(c crypter.Crypter) UserServiceOption {
+	return func(us *UserService) {
+		us.crypter = c
+	}
+}
+
+// WithLegacyCrypter assign crypter which hashed password before the current crypter,
+// it is only used to make failed login of user with such password take as long as other failed login
+func WithLegacyCrypter(c crypter.Crypter) UserServiceOption {
+	return func(us *UserService) {
+		us.legacyCrypter = c
+	}
+}
+
+func WithPasswordPolicy(passwordPolicy passwordpolicy.PasswordPolicy) UserServiceOption {
+	return func(us *UserService) {
+		us.passwordPolicy = passwordPolicy
+	}
+}
+
 func WithTxHandler(db database.IPostgres) UserServiceOption {
 	return func(us *UserService) {
 		us.txHandler = database.NewTxHandler(db)
@@ -37,18 +111,143 @@ func WithJWTGenerator(jwtGenerator jwt.JWTGenerator) UserServiceOption {
 	}
 }
 
+func WithJWTParser(jwtParser jwt.JWTParser) UserServiceOption {
+	return func(us *UserService) {
+		us.jwtParser = jwtParser
+	}
+}
+
+func WithJWTRevocationStore(revocationStore jwt.JWTRevocationStore) UserServiceOption {
+	return func(us *UserService) {
+		us.jwtRevocationStore = revocationStore
+	}
+}
+
+// WithAllowedAudiences assign audiences which can be requested on login
+func WithAllowedAudiences(audiences []string) UserServiceOption {
+	return func(us *UserService) {
+		us.allowedAudiences = audiences
+	}
+}
+
+func WithMailer(m mailer.Mailer) UserServiceOption {
+	return func(us *UserService) {
+		us.mailer = m
+	}
+}
+
+// WithPasswordResetURL assign url of page which handle reset token, token is appended as query param
+func WithPasswordResetURL(passwordResetURL string) UserServiceOption {
+	return func(us *UserService) {
+		us.passwordResetURL = passwordResetURL
+	}
+}
+
+func WithPasswordResetTokenExpireDuration(d time.Duration) UserServiceOption {
+	return func(us *UserService) {
+		us.passwordResetTokenExpireDuration = d
+	}
+}
+
+// WithEmailVerificationURL assign url of page which handle verification token, token is appended as query param
+func WithEmailVerificationURL(emailVerificationURL string) UserServiceOption {
+	return func(us *UserService) {
+		us.emailVerificationURL = emailVerificationURL
+	}
+}
+
+func WithEmailVerificationTokenExpireDuration(d time.Duration) UserServiceOption {
+	return func(us *UserService) {
+		us.emailVerificationTokenExpireDuration = d
+	}
+}
+
+// WithEmailVerificationRequired reject login of user whose email is not verified
+func WithEmailVerificationRequired(required bool) UserServiceOption {
+	return func(us *UserService) {
+		us.emailVerificationRequired = required
+	}
+}
+
+// WithLoginLockout track failed login in store and lock username from a client ip or the client ip according to its policy,
+// username alone is never locked so nobody can lock other user out
+func WithLoginLockout(store lockout.Store, usernamePolicy lockout.Policy, ipPolicy lockout.Policy) UserServiceOption {
+	return func(us *UserService) {
+		us.loginAttemptStore = store
+		us.usernameLockoutPolicy = usernamePolicy
+		us.ipLockoutPolicy = ipPolicy
+	}
+}
+
+// WithLoginDelay delay failed login of username which failed from any client ip, delay is the lock duration of policy,
+// successful login is never delayed, only applied when WithLoginLockout is set
+func WithLoginDelay(policy lockout.Policy) UserServiceOption {
+	return func(us *UserService) {
+		us.usernameDelayPolicy = policy
+	}
+}
+
+// WithMailRateLimit limit mail requested without login, e.g. password reset: mail is not sent again to the same user within cooldown,
+// and client ip is limited according to ipPolicy, only applied when WithLoginLockout is set
+func WithMailRateLimit(cooldown time.Duration, ipPolicy lockout.Policy) UserServiceOption {
+	return func(us *UserService) {
+		us.mailCooldown = cooldown
+		us.mailIPPolicy = ipPolicy
+	}
+}
+
+// WithMFA enable TOTP multi-factor authentication, secret is encrypted at rest using encrypter
+func WithMFA(t totp.TOTP, encrypter crypter.Encrypter) UserServiceOption {
+	return func(us *UserService) {
+		us.totp = t
+		us.mfaEncrypter = encrypter
+	}
+}
+
 type UserService struct {
-	userRepo      repoUser.IUserRepo
-	txHandler     database.TxHandler
-	uuidGenerator func() string
-	crypter       crypter.Crypter
-	jwtGenerator  jwt.JWTGenerator
+	userRepo                             repoUser.IUserRepo
+	sessionRepo                          repoUser.ISessionRepo
+	roleRepo                             repoRole.IRoleRepo
+	passwordResetTokenRepo               repoUser.IPasswordResetTokenRepo
+	emailVerificationTokenRepo           repoUser.IEmailVerificationTokenRepo
+	recoveryCodeRepo                     repoUser.IRecoveryCodeRepo
+	txHandler                            database.TxHandler
+	uuidGenerator                        func() string
+	crypter                              crypter.Crypter
+	legacyCrypter                        crypter.Crypter
+	passwordPolicy                       passwordpolicy.PasswordPolicy
+	jwtGenerator                         jwt.JWTGenerator
+	jwtParser                            jwt.JWTParser
+	jwtRevocationStore                   jwt.JWTRevocationStore
+	allowedAudiences                     []string
+	mailer                               mailer.Mailer
+	passwordResetURL                     string
+	passwordResetTokenExpireDuration     time.Duration
+	emailVerificationURL                 string
+	emailVerificationTokenExpireDuration time.Duration
+	emailVerificationRequired            bool
+	loginAttemptStore                    lockout.Store
+	usernameLockoutPolicy                lockout.Policy
+	ipLockoutPolicy                      lockout.Policy
+	usernameDelayPolicy                  lockout.Policy
+	mailCooldown                         time.Duration
+	mailIPPolicy                         lockout.Policy
+	totp                                 totp.TOTP
+	mfaEncrypter                         crypter.Encrypter
+	dummyPasswordHash                    *dummyPasswordHash
+	timeNowFunc                          func() time.Time
 }
 
 func NewUserService(options ...UserServiceOption) UserService {
 	res := &UserService{
-		uuidGenerator: uuid.NewString,
-		crypter:       crypter.New(),
+		uuidGenerator:                        uuid.NewString,
+		crypter:                              crypter.New(),
+		legacyCrypter:                        crypter.New(crypter.WithAlgorithm(crypter.AlgorithmBcrypt), crypter.WithBcryptCost(crypter.LegacyBcryptCost)),
+		passwordPolicy:                       passwordpolicy.NewPasswordPolicy(),
+		dummyPasswordHash:                    &dummyPasswordHash{},
+		passwordResetTokenExpireDuration:     30 * time.Minute,
+		emailVerificationTokenExpireDuration: 24 * time.Hour,
+		timeNowFunc:                          time.Now,
 	}
 
 	for _, apply := range options {
@@ -2,10 +2,17 @@ package crypter
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
 	pkgErr "golang-rest-api/pkg/error"
 	"golang-rest-api/pkg/log"
 	"net/http"
+	"runtime"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -13,34 +20,283 @@ var (
 	ErrFailedProcessPassword = pkgErr.NewCustomError("Failed Process Password", "FAILED_PROCESS_PASSWORD", http.StatusBadGateway)
 )
 
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+
+	// LegacyBcryptCost cost of bcrypt hash generated before the cost was configurable
+	LegacyBcryptCost = bcrypt.MinCost
+
+	argon2idPrefix = "$argon2id$"
+
+	// bound of argon2id parameters accepted from stored hash, so corrupted hash can not exhaust memory or cpu
+	maxArgon2Memory     = 1024 * 1024
+	maxArgon2Iterations = 100
+)
+
 //go:generate mockgen -destination=mock/crypter.go -package=mock transport-service/pkg/crypter Crypter
 type Crypter interface {
 	GenerateHash(ctx context.Context, password string) ([]byte, error)
 	IsPWAndHashPWMatch(ctx context.Context, password []byte, hashPass []byte) bool
+	// NeedsRehash return true when hash is not generated using current algorithm and parameters
+	NeedsRehash(hashPass []byte) bool
+}
+
+// Argon2Params parameters of argon2id, memory is in KiB
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+func (p Argon2Params) validate() error {
+	if p.Iterations < 1 || p.Iterations > maxArgon2Iterations {
+		return fmt.Errorf("argon2id iterations must be between 1 and %d", maxArgon2Iterations)
+	}
+
+	if p.Parallelism < 1 {
+		return fmt.Errorf("argon2id parallelism must be at least 1")
+	}
+
+	if p.Memory < 8*uint32(p.Parallelism) || p.Memory > maxArgon2Memory {
+		return fmt.Errorf("argon2id memory must be between 8 times parallelism and %d KiB", maxArgon2Memory)
+	}
+
+	return nil
+}
+
+type CrypterOption func(*crypter) error
+
+// WithAlgorithm assign algorithm of new hash, either AlgorithmArgon2id or AlgorithmBcrypt,
+// hash of both algorithms can always be verified
+func WithAlgorithm(algorithm string) CrypterOption {
+	return func(c *crypter) error {
+		if algorithm != AlgorithmArgon2id && algorithm != AlgorithmBcrypt {
+			return fmt.Errorf("unsupported password hash algorithm %q", algorithm)
+		}
+
+		c.algorithm = algorithm
+		return nil
+	}
+}
+
+func WithBcryptCost(cost int) CrypterOption {
+	return func(c *crypter) error {
+		if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+
+		c.bcryptCost = cost
+		return nil
+	}
+}
+
+func WithArgon2Params(params Argon2Params) CrypterOption {
+	return func(c *crypter) error {
+		err := params.validate()
+		if err != nil {
+			return err
+		}
+
+		if params.SaltLength < 8 || params.KeyLength < 16 {
+			return fmt.Errorf("argon2id salt length must be at least 8 and key length at least 16")
+		}
+
+		c.argon2Params = params
+		return nil
+	}
 }
 
-func New() crypter {
-	return crypter{}
+// WithArgon2Concurrency limit number of argon2id running at the same time, each one use Argon2Params.Memory,
+// non positive concurrency keep the default which is number of cpu
+func WithArgon2Concurrency(concurrency int) CrypterOption {
+	return func(c *crypter) error {
+		if concurrency > 0 {
+			c.argon2Slots = make(chan struct{}, concurrency)
+		}
+
+		return nil
+	}
 }
 
-type crypter struct{}
+// New create crypter which hash password using argon2id by default, panic when option is invalid
+func New(options ...CrypterOption) crypter {
+	c := &crypter{
+		algorithm:  AlgorithmArgon2id,
+		bcryptCost: 12,
+		argon2Params: Argon2Params{
+			Memory:      64 * 1024,
+			Iterations:  3,
+			Parallelism: 2,
+			SaltLength:  16,
+			KeyLength:   32,
+		},
+		argon2Slots: make(chan struct{}, runtime.NumCPU()),
+	}
+
+	for _, apply := range options {
+		err := apply(c)
+
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return *c
+}
+
+type crypter struct {
+	algorithm    string
+	bcryptCost   int
+	argon2Params Argon2Params
+	argon2Slots  chan struct{}
+}
 
 func (c crypter) GenerateHash(ctx context.Context, password string) ([]byte, error) {
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if c.algorithm == AlgorithmBcrypt {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), c.bcryptCost)
+		if err != nil {
+			log.Error(ctx, "failed generate hash password", err)
+			return nil, pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessPassword, err)
+		}
+
+		return passwordHash, nil
+	}
+
+	salt := make([]byte, c.argon2Params.SaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		log.Error(ctx, "failed generate salt", err)
+		return nil, pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessPassword, err)
+	}
+
+	p := c.argon2Params
+	key, err := c.argon2IDKey(ctx, []byte(password), salt, p)
 	if err != nil {
 		log.Error(ctx, "failed generate hash password", err)
 		return nil, pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessPassword, err)
 	}
 
-	return passwordHash, nil
+	// PHC string format, e.g. $argon2id$v=19$m=65536,t=3,p=2$salt$key
+	passwordHash := fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		p.Memory,
+		p.Iterations,
+		p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+
+	return []byte(passwordHash), nil
 }
 
 func (c crypter) IsPWAndHashPWMatch(ctx context.Context, password []byte, hashPass []byte) bool {
-	err := bcrypt.CompareHashAndPassword(hashPass, password)
+	if !strings.HasPrefix(string(hashPass), argon2idPrefix) {
+		err := bcrypt.CompareHashAndPassword(hashPass, password)
+		if err != nil {
+			log.Error(ctx, "error compare password", err)
+			return false
+		}
+
+		return true
+	}
+
+	p, salt, key, err := decodeArgon2idHash(hashPass)
+	if err != nil {
+		log.Error(ctx, "error decode argon2id hash", err)
+		return false
+	}
+
+	otherKey, err := c.argon2IDKey(ctx, password, salt, p)
 	if err != nil {
 		log.Error(ctx, "error compare password", err)
 		return false
 	}
 
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		log.Error(ctx, "error compare password", fmt.Errorf("password does not match argon2id hash"))
+		return false
+	}
+
 	return true
 }
+
+func (c crypter) NeedsRehash(hashPass []byte) bool {
+	if !strings.HasPrefix(string(hashPass), argon2idPrefix) {
+		if c.algorithm != AlgorithmBcrypt {
+			return true
+		}
+
+		cost, err := bcrypt.Cost(hashPass)
+		return err != nil || cost != c.bcryptCost
+	}
+
+	if c.algorithm != AlgorithmArgon2id {
+		return true
+	}
+
+	p, _, _, err := decodeArgon2idHash(hashPass)
+	return err != nil || p != c.argon2Params
+}
+
+// argon2IDKey derive key once a slot is free, so concurrent login can not allocate unbounded memory
+func (c crypter) argon2IDKey(ctx context.Context, password []byte, salt []byte, p Argon2Params) ([]byte, error) {
+	select {
+	case c.argon2Slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-c.argon2Slots }()
+
+	return argon2.IDKey(password, salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength), nil
+}
+
+// decodeArgon2idHash return parameters, salt and key of argon2id hash in PHC string format
+func decodeArgon2idHash(hashPass []byte) (Argon2Params, []byte, []byte, error) {
+	parts := strings.Split(string(hashPass), "$")
+	if len(parts) != 6 {
+		return Argon2Params{}, nil, nil, fmt.Errorf("invalid argon2id hash format")
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+
+	if version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	p := Argon2Params{}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism)
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+
+	err = p.validate()
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, err
+	}
+
+	if len(salt) == 0 || len(key) == 0 {
+		return Argon2Params{}, nil, nil, fmt.Errorf("argon2id hash has empty salt or key")
+	}
+
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+	return p, salt, key, nil
+}
@@ -0,0 +1,142 @@
+package crypter
+
+import (
+	"context"
+	"testing"
+)
+
+// testArgon2Params cheap parameters so test run fast
+var testArgon2Params = Argon2Params{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestCrypterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		crypter crypter
+	}{
+		{name: "argon2id", crypter: New(WithAlgorithm(AlgorithmArgon2id), WithArgon2Params(testArgon2Params))},
+		{name: "bcrypt", crypter: New(WithAlgorithm(AlgorithmBcrypt), WithBcryptCost(LegacyBcryptCost))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.crypter.GenerateHash(ctx, "Secret123!")
+			if err != nil {
+				t.Fatalf("GenerateHash() error = %v", err)
+			}
+
+			if !tt.crypter.IsPWAndHashPWMatch(ctx, []byte("Secret123!"), hash) {
+				t.Errorf("IsPWAndHashPWMatch() with correct password = false, want true")
+			}
+
+			if tt.crypter.IsPWAndHashPWMatch(ctx, []byte("Secret123?"), hash) {
+				t.Errorf("IsPWAndHashPWMatch() with wrong password = true, want false")
+			}
+
+			if tt.crypter.NeedsRehash(hash) {
+				t.Errorf("NeedsRehash() of own hash = true, want false")
+			}
+		})
+	}
+}
+
+func TestCrypterNeedsRehash(t *testing.T) {
+	ctx := context.Background()
+
+	argon2idCrypter := New(WithAlgorithm(AlgorithmArgon2id), WithArgon2Params(testArgon2Params))
+	bcryptCrypter := New(WithAlgorithm(AlgorithmBcrypt), WithBcryptCost(LegacyBcryptCost))
+
+	strongerParams := testArgon2Params
+	strongerParams.Iterations = 2
+	strongerArgon2idCrypter := New(WithAlgorithm(AlgorithmArgon2id), WithArgon2Params(strongerParams))
+	strongerBcryptCrypter := New(WithAlgorithm(AlgorithmBcrypt), WithBcryptCost(LegacyBcryptCost+1))
+
+	argon2idHash, err := argon2idCrypter.GenerateHash(ctx, "Secret123!")
+	if err != nil {
+		t.Fatalf("GenerateHash() error = %v", err)
+	}
+
+	bcryptHash, err := bcryptCrypter.GenerateHash(ctx, "Secret123!")
+	if err != nil {
+		t.Fatalf("GenerateHash() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		crypter crypter
+		hash    []byte
+		want    bool
+	}{
+		{name: "argon2id hash with same params", crypter: argon2idCrypter, hash: argon2idHash, want: false},
+		{name: "argon2id hash with other params", crypter: strongerArgon2idCrypter, hash: argon2idHash, want: true},
+		{name: "argon2id hash when algorithm is bcrypt", crypter: bcryptCrypter, hash: argon2idHash, want: true},
+		{name: "bcrypt hash with same cost", crypter: bcryptCrypter, hash: bcryptHash, want: false},
+		{name: "bcrypt hash with other cost", crypter: strongerBcryptCrypter, hash: bcryptHash, want: true},
+		{name: "bcrypt hash when algorithm is argon2id", crypter: argon2idCrypter, hash: bcryptHash, want: true},
+		{name: "malformed argon2id hash", crypter: argon2idCrypter, hash: []byte("$argon2id$v=19$m=64,t=1,p=1$$"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.crypter.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeArgon2idHashRejectInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "wrong format", hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ"},
+		{name: "unsupported version", hash: "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5"},
+		{name: "empty salt", hash: "$argon2id$v=19$m=64,t=1,p=1$$a2V5a2V5a2V5a2V5"},
+		{name: "empty key", hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$"},
+		{name: "zero iterations", hash: "$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5"},
+		{name: "too many iterations", hash: "$argon2id$v=19$m=64,t=101,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5"},
+		{name: "zero parallelism", hash: "$argon2id$v=19$m=64,t=1,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5"},
+		{name: "memory below 8 times parallelism", hash: "$argon2id$v=19$m=15,t=1,p=2$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5"},
+		{name: "too much memory", hash: "$argon2id$v=19$m=1048577,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := decodeArgon2idHash([]byte(tt.hash))
+			if err == nil {
+				t.Errorf("decodeArgon2idHash(%q) error = nil, want error", tt.hash)
+			}
+		})
+	}
+}
+
+func TestNewPanicOnInvalidOption(t *testing.T) {
+	tests := []struct {
+		name   string
+		option CrypterOption
+	}{
+		{name: "unknown algorithm", option: WithAlgorithm("argon2")},
+		{name: "bcrypt cost too low", option: WithBcryptCost(LegacyBcryptCost - 1)},
+		{name: "invalid argon2id params", option: WithArgon2Params(Argon2Params{Memory: 64, Iterations: 0, Parallelism: 1, SaltLength: 16, KeyLength: 32})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("New() did not panic")
+				}
+			}()
+
+			New(tt.option)
+		})
+	}
+}
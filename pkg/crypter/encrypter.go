@@ -0,0 +1,88 @@
+package crypter
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+	"net/http"
+)
+
+var (
+	ErrFailedProcessEncryption = pkgErr.NewCustomError("Failed Process Encryption", "FAILED_PROCESS_ENCRYPTION", http.StatusInternalServerError)
+)
+
+// Encrypter encrypt secret which must be readable again, e.g. TOTP secret,
+// password must be hashed using Crypter instead
+//
+//go:generate mockgen -destination=mock/encrypter.go -package=mock golang-rest-api/pkg/crypter Encrypter
+type Encrypter interface {
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+// NewAESGCMEncrypter create encrypter using AES-GCM, key is base64 encoded 16, 24 or 32 bytes key.
+// Ciphertext is base64 encoded nonce followed by sealed data
+func NewAESGCMEncrypter(key string) (aesGCMEncrypter, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return aesGCMEncrypter{}, fmt.Errorf("failed decode encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return aesGCMEncrypter{}, fmt.Errorf("failed create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return aesGCMEncrypter{}, fmt.Errorf("failed create gcm: %w", err)
+	}
+
+	return aesGCMEncrypter{
+		aead: aead,
+	}, nil
+}
+
+type aesGCMEncrypter struct {
+	aead cipher.AEAD
+}
+
+func (e aesGCMEncrypter) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		log.Error(ctx, "failed generate nonce", err)
+		return "", pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessEncryption, err)
+	}
+
+	sealed := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (e aesGCMEncrypter) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		log.Error(ctx, "failed decode ciphertext", err)
+		return "", pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessEncryption, err)
+	}
+
+	nonceSize := e.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		err := fmt.Errorf("ciphertext too short")
+		log.Error(ctx, "failed decrypt ciphertext", err)
+		return "", pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessEncryption, err)
+	}
+
+	plaintext, err := e.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		log.Error(ctx, "failed decrypt ciphertext", err)
+		return "", pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessEncryption, err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	pkgErr "golang-rest-api/pkg/error"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorInvalidCursor = pkgErr.NewCustomError("invalid cursor", "INVALID_CURSOR", http.StatusBadRequest)
+)
+
+// Cursor position of a row in keyset pagination ordered by (created_at, id),
+// sent to client as opaque token so the ordering can change without breaking client,
+// Scope bind the cursor to sort order and filters of the query which produce it
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+	Scope     string    `json:"s"`
+}
+
+// CursorScope return scope of query with the given sort order and filters,
+// cursor of a query can not be used with another query
+func CursorScope(sortOrder string, filters ...any) string {
+	b, _ := json.Marshal(append([]any{sortOrder}, filters...))
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor return cursor of token, token produced by query of another scope is rejected
+func DecodeCursor(token string, scope string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, pkgErr.NewCustomErrWithOriginalErr(ErrorInvalidCursor, err)
+	}
+
+	res := Cursor{}
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return Cursor{}, pkgErr.NewCustomErrWithOriginalErr(ErrorInvalidCursor, err)
+	}
+
+	if res.CreatedAt.IsZero() || res.Scope != scope {
+		return Cursor{}, ErrorInvalidCursor
+	}
+
+	_, err = uuid.Parse(res.ID)
+	if err != nil {
+		return Cursor{}, pkgErr.NewCustomErrWithOriginalErr(ErrorInvalidCursor, err)
+	}
+
+	return res, nil
+}
+
+// Condition return where condition which select rows after the cursor and its args,
+// createdAtColumn and idColumn must be trusted column name, firstArgPos is position of the first placeholder
+func (c Cursor) Condition(createdAtColumn string, idColumn string, desc bool, firstArgPos int) (string, []any) {
+	operator := ">"
+	if desc {
+		operator = "<"
+	}
+
+	condition := fmt.Sprintf("(%s, %s) %s ($%d, $%d)", createdAtColumn, idColumn, operator, firstArgPos, firstArgPos+1)
+	return condition, []any{c.CreatedAt, c.ID}
+}
+
+// KeysetPage trim rows which is fetched with limit+1 to limit and return token of the next page cursor,
+// token is empty when there is no more row
+func KeysetPage[T any](rows []T, limit int, cursorOf func(T) Cursor) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+
+	rows = rows[:limit]
+	return rows, cursorOf(rows[len(rows)-1]).Encode()
+}
@@ -9,6 +9,7 @@ type CustomError struct {
 	errorCode     string
 	statusCode    int
 	originalError error
+	details       any
 }
 
 func (err CustomError) Error() string {
@@ -27,6 +28,11 @@ func (err CustomError) GetErrorCode() string {
 	return err.errorCode
 }
 
+// GetDetails return additional information of the error shown to client, e.g. list of invalid field
+func (err CustomError) GetDetails() any {
+	return err.details
+}
+
 func NewCustomError(
 	message string,
 	errorCode string,
@@ -51,3 +57,11 @@ func NewCustomErrWithOriginalErr(
 		originalError: originalErr,
 	}
 }
+
+func NewCustomErrWithDetails(
+	customErr CustomError,
+	details any,
+) CustomError {
+	customErr.details = details
+	return customErr
+}
@@ -14,6 +14,7 @@ import (
 var (
 	ErrorUnauthorized      = pkgErr.NewCustomError("unauthorized", "UNAUTHORIZED", http.StatusUnauthorized)
 	ErrorJWTClaimsNotFound = pkgErr.NewCustomError("unauthorized: user jwt claims not found", "JWT_CLAIMS_NOT_FOUND", http.StatusUnauthorized)
+	ErrorSubjectInactive   = pkgErr.NewCustomError("unauthorized: token subject no longer active", "TOKEN_SUBJECT_INACTIVE", http.StatusUnauthorized)
 )
 
 type contexKey string
@@ -22,7 +23,36 @@ const (
 	contextKeyUserClaims contexKey = "user_claims"
 )
 
-func JWTAuthUser(parser jwt.JWTParser, cookieName string) func(next http.Handler) http.Handler {
+type JWTAuthUserOption func(*jwtAuthUserConfig)
+
+// ActiveSubjectCheckFunc return whether subject of token can still access the api, e.g. user is not deleted
+type ActiveSubjectCheckFunc func(ctx context.Context, subject string) (bool, error)
+
+type jwtAuthUserConfig struct {
+	revocationStore    jwt.JWTRevocationStore
+	activeSubjectCheck ActiveSubjectCheckFunc
+}
+
+// JWTAuthUserWithRevocationStore reject token which id (jti) is recorded in revocation store
+func JWTAuthUserWithRevocationStore(store jwt.JWTRevocationStore) JWTAuthUserOption {
+	return func(c *jwtAuthUserConfig) {
+		c.revocationStore = store
+	}
+}
+
+// JWTAuthUserWithActiveSubjectCheck reject token which subject is no longer active
+func JWTAuthUserWithActiveSubjectCheck(check ActiveSubjectCheckFunc) JWTAuthUserOption {
+	return func(c *jwtAuthUserConfig) {
+		c.activeSubjectCheck = check
+	}
+}
+
+func JWTAuthUser(parser jwt.JWTParser, cookieName string, options ...JWTAuthUserOption) func(next http.Handler) http.Handler {
+	config := &jwtAuthUserConfig{}
+	for _, apply := range options {
+		apply(config)
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
@@ -33,12 +63,38 @@ func JWTAuthUser(parser jwt.JWTParser, cookieName string) func(next http.Handler
 					return
 				}
 
-				tokenClaims, err := parser.ParseAndValidate(ctx, tokenString)
+				tokenClaims, err := parser.ParseAndValidateWithTokenType(ctx, tokenString, jwt.JWTTokenTypeAccess)
 				if err != nil {
 					httpserver.WriteJsonError(ctx, w, pkgErr.NewCustomErrWithOriginalErr(ErrorUnauthorized, err))
 					return
 				}
 
+				if config.revocationStore != nil {
+					revoked, err := config.revocationStore.IsRevoked(ctx, tokenClaims.ID)
+					if err != nil {
+						httpserver.WriteJsonError(ctx, w, err)
+						return
+					}
+
+					if revoked {
+						httpserver.WriteJsonError(ctx, w, pkgErr.NewCustomErrWithOriginalErr(ErrorUnauthorized, jwt.ErrorJWTRevoked))
+						return
+					}
+				}
+
+				if config.activeSubjectCheck != nil {
+					active, err := config.activeSubjectCheck(ctx, tokenClaims.Subject)
+					if err != nil {
+						httpserver.WriteJsonError(ctx, w, err)
+						return
+					}
+
+					if !active {
+						httpserver.WriteJsonError(ctx, w, ErrorSubjectInactive)
+						return
+					}
+				}
+
 				r = r.WithContext(context.WithValue(ctx, contextKeyUserClaims, tokenClaims))
 				next.ServeHTTP(w, r)
 			})
@@ -0,0 +1,92 @@
+package httpmiddleware
+
+import (
+	"fmt"
+	pkgErr "golang-rest-api/pkg/error"
+	httpserver "golang-rest-api/pkg/http_server"
+	"net/http"
+	"slices"
+)
+
+var (
+	ErrorForbidden          = pkgErr.NewCustomError("forbidden", "FORBIDDEN", http.StatusForbidden)
+	ErrorAuthMethodRequired = pkgErr.NewCustomError("forbidden: multi-factor authentication required", "AUTH_METHOD_REQUIRED", http.StatusForbidden)
+)
+
+// RequireRole only allow user which has at least one of roles,
+// should be used after JWTAuthUser
+func RequireRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				claims, err := GetUserClaims(ctx)
+				if err != nil {
+					httpserver.WriteJsonError(ctx, w, err)
+					return
+				}
+
+				for _, role := range roles {
+					if slices.Contains(claims.Roles, role) {
+						next.ServeHTTP(w, r)
+						return
+					}
+				}
+
+				err = fmt.Errorf("user %s does not have any of roles %v", claims.Subject, roles)
+				httpserver.WriteJsonError(ctx, w, pkgErr.NewCustomErrWithOriginalErr(ErrorForbidden, err))
+			})
+	}
+}
+
+// RequirePermission only allow user which has all of permissions,
+// should be used after JWTAuthUser
+func RequirePermission(permissions ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				claims, err := GetUserClaims(ctx)
+				if err != nil {
+					httpserver.WriteJsonError(ctx, w, err)
+					return
+				}
+
+				for _, permission := range permissions {
+					if !slices.Contains(claims.Permissions, permission) {
+						err := fmt.Errorf("user %s does not have permission %s", claims.Subject, permission)
+						httpserver.WriteJsonError(ctx, w, pkgErr.NewCustomErrWithOriginalErr(ErrorForbidden, err))
+						return
+					}
+				}
+
+				next.ServeHTTP(w, r)
+			})
+	}
+}
+
+// RequireAuthMethod only allow token which user authenticated using all of methods (amr claim),
+// e.g. jwt.AuthMethodOTP to require multi-factor authentication, should be used after JWTAuthUser
+func RequireAuthMethod(methods ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				claims, err := GetUserClaims(ctx)
+				if err != nil {
+					httpserver.WriteJsonError(ctx, w, err)
+					return
+				}
+
+				for _, method := range methods {
+					if !slices.Contains(claims.AuthMethods, method) {
+						err := fmt.Errorf("user %s is not authenticated using %s", claims.Subject, method)
+						httpserver.WriteJsonError(ctx, w, pkgErr.NewCustomErrWithOriginalErr(ErrorAuthMethodRequired, err))
+						return
+					}
+				}
+
+				next.ServeHTTP(w, r)
+			})
+	}
+}
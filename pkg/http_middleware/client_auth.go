@@ -0,0 +1,50 @@
+package httpmiddleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	pkgErr "golang-rest-api/pkg/error"
+	httpserver "golang-rest-api/pkg/http_server"
+	"golang-rest-api/pkg/log"
+	"net/http"
+)
+
+var (
+	ErrorInvalidClient = pkgErr.NewCustomError("invalid client credentials", "INVALID_CLIENT", http.StatusUnauthorized)
+)
+
+// BasicClientAuth only allow trusted client which send its client id and secret
+// using HTTP basic authentication, clients is map of client id to client secret
+func BasicClientAuth(clients map[string]string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				clientID, clientSecret, ok := r.BasicAuth()
+				if !ok || !isValidClientSecret(clients, clientID, clientSecret) {
+					err := fmt.Errorf("invalid client credentials for client %q", clientID)
+					log.Error(ctx, "invalid client credentials", err)
+
+					w.Header().Set("WWW-Authenticate", `Basic realm="client"`)
+					httpserver.WriteJsonError(ctx, w, pkgErr.NewCustomErrWithOriginalErr(ErrorInvalidClient, err))
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			})
+	}
+}
+
+func isValidClientSecret(clients map[string]string, clientID string, clientSecret string) bool {
+	expectedSecret, ok := clients[clientID]
+	if !ok || len(expectedSecret) == 0 {
+		return false
+	}
+
+	// compare hash so the comparison time does not depend on secret length
+	expectedHash := sha256.Sum256([]byte(expectedSecret))
+	hash := sha256.Sum256([]byte(clientSecret))
+
+	return subtle.ConstantTimeCompare(expectedHash[:], hash[:]) == 1
+}
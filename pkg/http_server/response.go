@@ -19,6 +19,7 @@ type HttpErrorResponse struct {
 	Message    string `json:"message,omitempty"`
 	HTTPStatus int    `json:"http_status"`
 	ErrorCode  string `json:"error_code"`
+	Errors     any    `json:"errors,omitempty"`
 }
 
 type PaginationMetaInfo struct {
@@ -27,6 +28,12 @@ type PaginationMetaInfo struct {
 	Total  int `json:"total"`
 }
 
+// CursorPaginationMetaInfo meta of keyset paginated list, NextCursor is empty on the last page
+type CursorPaginationMetaInfo struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor"`
+}
+
 const (
 	HeaderKeyContentType  = "Content-Type"
 	HeaderApplicationJson = "application/json"
@@ -48,6 +55,23 @@ func WriteJsonMsgWithData(ctx context.Context, w http.ResponseWriter, statusCode
 	}
 }
 
+func WriteJsonMsgWithDataAndMeta(ctx context.Context, w http.ResponseWriter, statusCode int, msg string, data any, meta any) {
+	hr := HttpSuccessResponse{
+		HTTPStatus: statusCode,
+		Message:    msg,
+		Data:       data,
+		Meta:       meta,
+	}
+
+	w.Header().Set(HeaderKeyContentType, HeaderApplicationJson)
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(hr)
+	if err != nil {
+		log.Error(ctx, "error http write reponse", err)
+	}
+}
+
 func WriteJsonMsgOnly(ctx context.Context, w http.ResponseWriter, statusCode int, msg string) {
 	hr := HttpSuccessResponse{
 		HTTPStatus: statusCode,
@@ -63,6 +87,18 @@ func WriteJsonMsgOnly(ctx context.Context, w http.ResponseWriter, statusCode int
 	}
 }
 
+// WriteJson write data as json body without success response envelope,
+// used by endpoint which response format is defined by a standard
+func WriteJson(ctx context.Context, w http.ResponseWriter, statusCode int, data any) {
+	w.Header().Set(HeaderKeyContentType, HeaderApplicationJson)
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		log.Error(ctx, "error http write reponse", err)
+	}
+}
+
 func WriteJsonError(ctx context.Context, w http.ResponseWriter, err error) {
 	hr := HttpErrorResponse{
 		HTTPStatus: http.StatusInternalServerError,
@@ -73,6 +109,7 @@ func WriteJsonError(ctx context.Context, w http.ResponseWriter, err error) {
 	if err, ok := err.(pkgErr.CustomError); ok {
 		hr.ErrorCode = err.GetErrorCode()
 		hr.HTTPStatus = err.GetStatusCode()
+		hr.Errors = err.GetDetails()
 	}
 
 	w.Header().Set(HeaderKeyContentType, HeaderApplicationJson)
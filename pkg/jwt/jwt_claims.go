@@ -4,6 +4,21 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+type JWTTokenType string
+
+const (
+	JWTTokenTypeAccess  JWTTokenType = "access"
+	JWTTokenTypeRefresh JWTTokenType = "refresh"
+	// JWTTokenTypeMFAPending issued after password is verified, only exchangeable with access token by verifying MFA code
+	JWTTokenTypeMFAPending JWTTokenType = "mfa_pending"
+)
+
+// authentication method reference (amr) values as described in RFC 8176
+const (
+	AuthMethodPassword = "pwd"
+	AuthMethodOTP      = "otp"
+)
+
 type JWTClaims struct {
 	ExpireAt  *jwt.NumericDate `json:"exp"`
 	NotBefore *jwt.NumericDate `json:"nbf"`
@@ -11,6 +26,12 @@ type JWTClaims struct {
 	Audience  jwt.ClaimStrings `json:"aud"`
 	Issuer    string           `json:"iss"`
 	Subject   string           `json:"sub"`
+	ID        string           `json:"jti"`
+	TokenType JWTTokenType     `json:"typ"`
+
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	AuthMethods []string `json:"amr,omitempty"`
 }
 
 func (c JWTClaims) GetExpirationTime() (*jwt.NumericDate, error) {
@@ -9,59 +9,75 @@ import (
 	"golang-rest-api/pkg/log"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrFailedProcessJWT            = pkgErr.NewCustomError("Failed Process JWT", "FAILED_PROCESS_JWT", http.StatusInternalServerError)
 	ErrFailedJWTMethodNotSupported = pkgErr.NewCustomError("JWT Method Not Supported", "JWT_METHOD_NOT_SUPPORTED", http.StatusInternalServerError)
+	ErrFailedJWTKeyNotMatchMethod  = pkgErr.NewCustomError("JWT Key Not Match Method", "JWT_KEY_NOT_MATCH_METHOD", http.StatusInternalServerError)
 )
 
 type User struct {
-	ID       string
-	Username string
+	ID          string
+	Username    string
+	Roles       []string
+	Permissions []string
+	// AuthMethods methods used to authenticate user, e.g. AuthMethodPassword
+	AuthMethods []string
 }
 
 type JWTResult struct {
 	AccessToken           string
+	AccessTokenID         string
 	ExpiresAt             time.Time
 	RefreshToken          string
+	RefreshTokenID        string
 	RefreshTokenExpiresAt time.Time
 }
 
+type JWTMFAPendingResult struct {
+	Token     string
+	TokenID   string
+	ExpiresAt time.Time
+}
+
 //go:generate mockgen -destination=mock/jwt_generator.go -package=mock golang-rest-api/pkg/jwt JWTGenerator
 type JWTGenerator interface {
 	GenerateJWT(ctx context.Context, user User) (JWTResult, error)
+	GenerateJWTForAudience(ctx context.Context, user User, audience ...string) (JWTResult, error)
+	GenerateMFAPendingJWT(ctx context.Context, userID string, audience ...string) (JWTMFAPendingResult, error)
 }
 
 type JWTGeneratorOptions func(*jwtGenerator) error
 
 // JWTGeneratorWithSigningMethod assign jwt signing method and jwt key
-// IF method is RSA key should fill by private key
+// IF method is RSA, ECDSA or EdDSA key should fill by private key in PEM format
 func JWTGeneratorWithSigningMethod(methodName JWTSigningMethodName, key string) JWTGeneratorOptions {
 	return func(jg *jwtGenerator) error {
 		jg.jwtSigningMethodName = methodName
 		jg.signingMethod = methodName.GetSigningMethod()
 
 		jg.jwtKeyString = key
-		switch jg.jwtSigningMethodName.GetFamily() {
-		case JWTFamilyHMACSHA:
-			jg.jwtKey = []byte(jg.jwtKeyString)
-
-		case JWTFamilyRSA:
-			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(jg.jwtKeyString))
-			if err != nil {
-				return err
-			}
-			jg.jwtKey = privateKey
-
-		default:
-			return ErrFailedJWTMethodNotSupported
+		jwtKey, err := parseSigningKey(methodName, key)
+		if err != nil {
+			return err
 		}
+		jg.jwtKey = jwtKey
 
 		return nil
 	}
 }
 
+// JWTGeneratorWithSigningKey same with JWTGeneratorWithSigningMethod
+// and put keyID as kid header, so parser can pick the right key when signing key is rotated
+func JWTGeneratorWithSigningKey(keyID string, methodName JWTSigningMethodName, key string) JWTGeneratorOptions {
+	return func(jg *jwtGenerator) error {
+		jg.keyID = keyID
+		return JWTGeneratorWithSigningMethod(methodName, key)(jg)
+	}
+}
+
 func JWTGeneratorWithExpireDuration(duration time.Duration) JWTGeneratorOptions {
 	return func(jg *jwtGenerator) error {
 		jg.expireDuration = duration
@@ -76,6 +92,13 @@ func JWTGeneratorWithRefreshTokenExpireDurationI(duration time.Duration) JWTGene
 	}
 }
 
+func JWTGeneratorWithMFAPendingExpireDuration(duration time.Duration) JWTGeneratorOptions {
+	return func(jg *jwtGenerator) error {
+		jg.mfaPendingExpireDuration = duration
+		return nil
+	}
+}
+
 func JWTGeneratorWithIssuer(issuer string) JWTGeneratorOptions {
 	return func(jg *jwtGenerator) error {
 		jg.issuer = issuer
@@ -83,11 +106,21 @@ func JWTGeneratorWithIssuer(issuer string) JWTGeneratorOptions {
 	}
 }
 
+// JWTGeneratorWithAudience assign default audience (aud) of generated jwt
+func JWTGeneratorWithAudience(audience ...string) JWTGeneratorOptions {
+	return func(jg *jwtGenerator) error {
+		jg.audience = audience
+		return nil
+	}
+}
+
 func NewJWTGenerator(options ...JWTGeneratorOptions) jwtGenerator {
 	gen := &jwtGenerator{
 		timeNowFunc:                time.Now,
+		idGenerator:                uuid.NewString,
 		expireDuration:             24 * time.Hour,
 		refreshTokenExpireDuration: 48 * time.Hour,
+		mfaPendingExpireDuration:   5 * time.Minute,
 	}
 
 	for _, apply := range options {
@@ -105,26 +138,47 @@ func NewJWTGenerator(options ...JWTGeneratorOptions) jwtGenerator {
 
 type jwtGenerator struct {
 	jwtKeyString string
-	// IF signing method is RSA should be filled by private key
+	// IF signing method is RSA, ECDSA or EdDSA should be filled by private key
 	jwtKey                     any
+	keyID                      string
 	jwtSigningMethodName       JWTSigningMethodName
 	signingMethod              jwt.SigningMethod
 	expireDuration             time.Duration
 	refreshTokenExpireDuration time.Duration
+	mfaPendingExpireDuration   time.Duration
 	issuer                     string
+	audience                   []string
 	timeNowFunc                func() time.Time
+	idGenerator                func() string
 }
 
 func (jg jwtGenerator) GenerateJWT(ctx context.Context, u User) (JWTResult, error) {
+	return jg.GenerateJWTForAudience(ctx, u, jg.audience...)
+}
+
+// GenerateJWTForAudience generate jwt which only valid for the given audience,
+// default audience is used when audience is empty
+func (jg jwtGenerator) GenerateJWTForAudience(ctx context.Context, u User, audience ...string) (JWTResult, error) {
+	if len(audience) == 0 {
+		audience = jg.audience
+	}
+
 	now := jg.timeNowFunc()
 	claims := JWTClaims{
-		ExpireAt: &jwt.NumericDate{Time: now.Add(jg.expireDuration)},
-		IssuedAt: &jwt.NumericDate{Time: now},
-		Issuer:   jg.issuer,
-		Subject:  u.ID,
+		ExpireAt:  &jwt.NumericDate{Time: now.Add(jg.expireDuration)},
+		IssuedAt:  &jwt.NumericDate{Time: now},
+		Audience:  audience,
+		Issuer:    jg.issuer,
+		Subject:   u.ID,
+		TokenType: JWTTokenTypeAccess,
+		ID:        jg.idGenerator(),
+
+		Roles:       u.Roles,
+		Permissions: u.Permissions,
+		AuthMethods: u.AuthMethods,
 	}
 
-	token := jwt.NewWithClaims(jg.signingMethod, claims)
+	token := jg.newToken(claims)
 	tokenString, err := token.SignedString(jg.jwtKey)
 	if err != nil {
 		log.Error(ctx, "error get signed string access token", err)
@@ -133,7 +187,9 @@ func (jg jwtGenerator) GenerateJWT(ctx context.Context, u User) (JWTResult, erro
 
 	refreshTokenClaims := claims
 	refreshTokenClaims.ExpireAt = &jwt.NumericDate{Time: now.Add(jg.refreshTokenExpireDuration)}
-	refreshToken := jwt.NewWithClaims(jg.signingMethod, refreshTokenClaims)
+	refreshTokenClaims.TokenType = JWTTokenTypeRefresh
+	refreshTokenClaims.ID = jg.idGenerator()
+	refreshToken := jg.newToken(refreshTokenClaims)
 	refreshTokenString, err := refreshToken.SignedString(jg.jwtKey)
 	if err != nil {
 		log.Error(ctx, "error get signed string refresh token", err)
@@ -142,8 +198,50 @@ func (jg jwtGenerator) GenerateJWT(ctx context.Context, u User) (JWTResult, erro
 
 	return JWTResult{
 		AccessToken:           tokenString,
+		AccessTokenID:         claims.ID,
 		ExpiresAt:             claims.ExpireAt.Time,
 		RefreshToken:          refreshTokenString,
+		RefreshTokenID:        refreshTokenClaims.ID,
 		RefreshTokenExpiresAt: refreshTokenClaims.ExpireAt.Time,
 	}, nil
 }
+
+// GenerateMFAPendingJWT generate short lived token which prove password of user has been verified,
+// the token carry no roles or permissions and is rejected as access token
+func (jg jwtGenerator) GenerateMFAPendingJWT(ctx context.Context, userID string, audience ...string) (JWTMFAPendingResult, error) {
+	if len(audience) == 0 {
+		audience = jg.audience
+	}
+
+	now := jg.timeNowFunc()
+	claims := JWTClaims{
+		ExpireAt:  &jwt.NumericDate{Time: now.Add(jg.mfaPendingExpireDuration)},
+		IssuedAt:  &jwt.NumericDate{Time: now},
+		Audience:  audience,
+		Issuer:    jg.issuer,
+		Subject:   userID,
+		TokenType: JWTTokenTypeMFAPending,
+		ID:        jg.idGenerator(),
+	}
+
+	tokenString, err := jg.newToken(claims).SignedString(jg.jwtKey)
+	if err != nil {
+		log.Error(ctx, "error get signed string mfa pending token", err)
+		return JWTMFAPendingResult{}, pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessJWT, err)
+	}
+
+	return JWTMFAPendingResult{
+		Token:     tokenString,
+		TokenID:   claims.ID,
+		ExpiresAt: claims.ExpireAt.Time,
+	}, nil
+}
+
+func (jg jwtGenerator) newToken(claims JWTClaims) *jwt.Token {
+	token := jwt.NewWithClaims(jg.signingMethod, claims)
+	if len(jg.keyID) > 0 {
+		token.Header["kid"] = jg.keyID
+	}
+
+	return token
+}
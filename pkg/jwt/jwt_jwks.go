@@ -0,0 +1,83 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+)
+
+// JSONWebKey public key in JWK format (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+//go:generate mockgen -destination=mock/jwks_provider.go -package=mock golang-rest-api/pkg/jwt JWKSProvider
+type JWKSProvider interface {
+	JWKS() JSONWebKeySet
+}
+
+// JWKS return public keys of the parser key set,
+// HMAC-SHA keys are secret so never published
+func (jp jwtParser) JWKS() JSONWebKeySet {
+	res := JSONWebKeySet{
+		Keys: []JSONWebKey{},
+	}
+
+	for keyID, k := range jp.keys {
+		switch publicKey := k.jwtKey.(type) {
+		case *rsa.PublicKey:
+			res.Keys = append(res.Keys, JSONWebKey{
+				KeyType:   "RSA",
+				Use:       "sig",
+				Algorithm: k.signingMethod.Alg(),
+				KeyID:     keyID,
+				N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+
+		case *ecdsa.PublicKey:
+			// coordinate is padded to curve size as required by RFC 7518
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			res.Keys = append(res.Keys, JSONWebKey{
+				KeyType:   "EC",
+				Use:       "sig",
+				Algorithm: k.signingMethod.Alg(),
+				KeyID:     keyID,
+				Curve:     publicKey.Curve.Params().Name,
+				X:         base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size))),
+				Y:         base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size))),
+			})
+
+		case ed25519.PublicKey:
+			res.Keys = append(res.Keys, JSONWebKey{
+				KeyType:   "OKP",
+				Use:       "sig",
+				Algorithm: k.signingMethod.Alg(),
+				KeyID:     keyID,
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+
+	sort.Slice(res.Keys, func(i, j int) bool {
+		return res.Keys[i].KeyID < res.Keys[j].KeyID
+	})
+
+	return res
+}
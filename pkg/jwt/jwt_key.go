@@ -0,0 +1,70 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// parseSigningKey parse key used to sign jwt based on signing method family
+// IF method is RSA, ECDSA or EdDSA key should be private key in PEM format
+func parseSigningKey(methodName JWTSigningMethodName, key string) (any, error) {
+	switch methodName.GetFamily() {
+	case JWTFamilyHMACSHA:
+		return []byte(key), nil
+
+	case JWTFamilyRSA:
+		return jwt.ParseRSAPrivateKeyFromPEM([]byte(key))
+
+	case JWTFamilyECDSA:
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+
+		return privateKey, validateECDSACurve(methodName, &privateKey.PublicKey)
+
+	case JWTFamilyEdDSA:
+		return jwt.ParseEdPrivateKeyFromPEM([]byte(key))
+
+	default:
+		return nil, ErrFailedJWTMethodNotSupported
+	}
+}
+
+// parseVerificationKey parse key used to verify jwt based on signing method family
+// IF method is RSA, ECDSA or EdDSA key should be public key in PEM format
+func parseVerificationKey(methodName JWTSigningMethodName, key string) (any, error) {
+	switch methodName.GetFamily() {
+	case JWTFamilyHMACSHA:
+		return []byte(key), nil
+
+	case JWTFamilyRSA:
+		return jwt.ParseRSAPublicKeyFromPEM([]byte(key))
+
+	case JWTFamilyECDSA:
+		publicKey, err := jwt.ParseECPublicKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+
+		return publicKey, validateECDSACurve(methodName, publicKey)
+
+	case JWTFamilyEdDSA:
+		return jwt.ParseEdPublicKeyFromPEM([]byte(key))
+
+	default:
+		return nil, ErrFailedJWTMethodNotSupported
+	}
+}
+
+// validateECDSACurve make sure curve of the key is the one required by signing method,
+// e.g. ES256 require P-256 key
+func validateECDSACurve(methodName JWTSigningMethodName, publicKey *ecdsa.PublicKey) error {
+	signingMethod, ok := methodName.GetSigningMethod().(*jwt.SigningMethodECDSA)
+	if !ok || publicKey.Curve.Params().BitSize != signingMethod.CurveBits {
+		return ErrFailedJWTKeyNotMatchMethod
+	}
+
+	return nil
+}
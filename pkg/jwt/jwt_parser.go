@@ -6,6 +6,7 @@ import (
 	pkgErr "golang-rest-api/pkg/error"
 	"golang-rest-api/pkg/log"
 	"net/http"
+	"slices"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -13,6 +14,7 @@ import (
 //go:generate mockgen -destination=mock/jwt_validator.go -package=mock golang-rest-api/pkg/jwt JWTValidator
 type JWTParser interface {
 	ParseAndValidate(ctx context.Context, tokenString string) (JWTClaims, error)
+	ParseAndValidateWithTokenType(ctx context.Context, tokenString string, tokenType JWTTokenType) (JWTClaims, error)
 }
 
 var (
@@ -22,26 +24,27 @@ var (
 type JWTParserOptions func(*jwtParser) error
 
 // JWTParserWithSigningMethod assign jwt signing method and jwt key
-// IF method is RSA key should fill by public key
+// used to verify token without kid header
+// IF method is RSA, ECDSA or EdDSA key should fill by public key in PEM format
 func JWTParserWithSigningMethod(methodName JWTSigningMethodName, key string) JWTParserOptions {
+	return JWTParserWithVerificationKey("", methodName, key)
+}
+
+// JWTParserWithVerificationKey add jwt key to the parser key set, token is verified
+// by the key which id equal with its kid header, can be applied multiple times
+// so token signed by previous key still valid after signing key is rotated
+// IF method is RSA, ECDSA or EdDSA key should fill by public key in PEM format
+func JWTParserWithVerificationKey(keyID string, methodName JWTSigningMethodName, key string) JWTParserOptions {
 	return func(jp *jwtParser) error {
-		jp.jwtSigningMethodName = methodName
-		jp.signingMethod = methodName.GetSigningMethod()
-
-		jp.jwtKeyString = key
-		switch jp.jwtSigningMethodName.GetFamily() {
-		case JWTFamilyHMACSHA:
-			jp.jwtKey = []byte(jp.jwtKeyString)
-
-		case JWTFamilyRSA:
-			privateKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(jp.jwtKeyString))
-			if err != nil {
-				return err
-			}
-			jp.jwtKey = privateKey
-
-		default:
-			return ErrFailedJWTMethodNotSupported
+		jwtKey, err := parseVerificationKey(methodName, key)
+		if err != nil {
+			return err
+		}
+
+		jp.keys[keyID] = jwtVerificationKey{
+			jwtSigningMethodName: methodName,
+			signingMethod:        methodName.GetSigningMethod(),
+			jwtKey:               jwtKey,
 		}
 
 		return nil
@@ -55,8 +58,18 @@ func JWTParserWithValidIssuer(issuer string) JWTParserOptions {
 	}
 }
 
+// JWTParserWithValidAudience only accept token which audience (aud) contains one of audience
+func JWTParserWithValidAudience(audience ...string) JWTParserOptions {
+	return func(jp *jwtParser) error {
+		jp.validAudience = audience
+		return nil
+	}
+}
+
 func NewJWTParser(options ...JWTParserOptions) jwtParser {
-	v := &jwtParser{}
+	v := &jwtParser{
+		keys: make(map[string]jwtVerificationKey),
+	}
 
 	for _, apply := range options {
 		err := apply(v)
@@ -71,21 +84,26 @@ func NewJWTParser(options ...JWTParserOptions) jwtParser {
 	return *v
 }
 
-type jwtParser struct {
-	jwtKeyString string
-	// IF signing method is RSA should be filled by public key
+type jwtVerificationKey struct {
+	// IF signing method is RSA, ECDSA or EdDSA should be filled by public key
 	jwtKey               any
 	jwtSigningMethodName JWTSigningMethodName
 	signingMethod        jwt.SigningMethod
-	validIssuer          string
+}
+
+type jwtParser struct {
+	// keys indexed by key id, key for token without kid header indexed by empty string
+	keys          map[string]jwtVerificationKey
+	validIssuer   string
+	validAudience []string
 }
 
 func (jp jwtParser) ParseAndValidate(ctx context.Context, tokenString string) (JWTClaims, error) {
 	jwtToken, err := jwt.ParseWithClaims(
 		tokenString,
 		&JWTClaims{},
-		func(t *jwt.Token) (any, error) { return jp.jwtKey, nil },
-		jwt.WithValidMethods([]string{jp.signingMethod.Alg()}),
+		jp.keyFunc,
+		jwt.WithValidMethods(jp.validMethods()),
 		jwt.WithIssuedAt(),
 		jwt.WithExpirationRequired(),
 		jwt.WithIssuer(jp.validIssuer),
@@ -102,5 +120,66 @@ func (jp jwtParser) ParseAndValidate(ctx context.Context, tokenString string) (J
 		return JWTClaims{}, pkgErr.NewCustomErrWithOriginalErr(ErrorJWTInvalid, err)
 	}
 
+	if !jp.isValidAudience(jwtClaims.Audience) {
+		err := fmt.Errorf("invalid jwt audience: %v", jwtClaims.Audience)
+		log.Error(ctx, "error audience when validate jwt", err)
+		return JWTClaims{}, pkgErr.NewCustomErrWithOriginalErr(ErrorJWTInvalid, err)
+	}
+
 	return *jwtClaims, nil
 }
+
+// ParseAndValidateWithTokenType parse and validate jwt like ParseAndValidate
+// and reject the token when typ claim is not equal with tokenType
+func (jp jwtParser) ParseAndValidateWithTokenType(ctx context.Context, tokenString string, tokenType JWTTokenType) (JWTClaims, error) {
+	jwtClaims, err := jp.ParseAndValidate(ctx, tokenString)
+	if err != nil {
+		return JWTClaims{}, err
+	}
+
+	if jwtClaims.TokenType != tokenType {
+		err := fmt.Errorf("invalid jwt token type, expected: %s, got: %s", tokenType, jwtClaims.TokenType)
+		log.Error(ctx, "error token type when validate jwt", err)
+		return JWTClaims{}, pkgErr.NewCustomErrWithOriginalErr(ErrorJWTInvalid, err)
+	}
+
+	return jwtClaims, nil
+}
+
+func (jp jwtParser) keyFunc(t *jwt.Token) (any, error) {
+	keyID, _ := t.Header["kid"].(string)
+
+	verificationKey, ok := jp.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("jwt key with kid %q not found", keyID)
+	}
+
+	if t.Method.Alg() != verificationKey.signingMethod.Alg() {
+		return nil, fmt.Errorf("jwt signing method %s not match with key %q", t.Method.Alg(), keyID)
+	}
+
+	return verificationKey.jwtKey, nil
+}
+
+func (jp jwtParser) validMethods() []string {
+	methods := make([]string, 0, len(jp.keys))
+	for _, k := range jp.keys {
+		methods = append(methods, k.signingMethod.Alg())
+	}
+
+	return methods
+}
+
+func (jp jwtParser) isValidAudience(audience jwt.ClaimStrings) bool {
+	if len(jp.validAudience) == 0 {
+		return true
+	}
+
+	for _, aud := range audience {
+		if slices.Contains(jp.validAudience, aud) {
+			return true
+		}
+	}
+
+	return false
+}
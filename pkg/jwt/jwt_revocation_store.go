@@ -0,0 +1,60 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	pkgErr "golang-rest-api/pkg/error"
+)
+
+var (
+	ErrorJWTRevoked = pkgErr.NewCustomError("jwt revoked", "REVOKED_JWT", http.StatusUnauthorized)
+)
+
+// JWTRevocationStore keep id (jti) of revoked token until the token expired
+//
+//go:generate mockgen -destination=mock/jwt_revocation_store.go -package=mock golang-rest-api/pkg/jwt JWTRevocationStore
+type JWTRevocationStore interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// NewInMemoryRevocationStore create revocation store that only live in process memory,
+// should only be used for single instance deployment
+func NewInMemoryRevocationStore() *inMemoryRevocationStore {
+	return &inMemoryRevocationStore{
+		revokedTokens: make(map[string]time.Time),
+		timeNowFunc:   time.Now,
+	}
+}
+
+type inMemoryRevocationStore struct {
+	mu            sync.RWMutex
+	revokedTokens map[string]time.Time
+	timeNowFunc   func() time.Time
+}
+
+func (s *inMemoryRevocationStore) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNowFunc()
+	for id, exp := range s.revokedTokens {
+		if exp.Before(now) {
+			delete(s.revokedTokens, id)
+		}
+	}
+
+	s.revokedTokens[tokenID] = expiresAt
+	return nil
+}
+
+func (s *inMemoryRevocationStore) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.revokedTokens[tokenID]
+	return ok, nil
+}
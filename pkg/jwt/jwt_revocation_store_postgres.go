@@ -0,0 +1,55 @@
+package jwt
+
+import (
+	"context"
+	"time"
+
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+)
+
+// NewPostgresRevocationStore create revocation store backed by revoked_tokens table,
+// can be shared across multiple instances
+func NewPostgresRevocationStore(db database.IPostgres) postgresRevocationStore {
+	return postgresRevocationStore{
+		db: db,
+	}
+}
+
+type postgresRevocationStore struct {
+	db database.IPostgres
+}
+
+func (s postgresRevocationStore) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	query := `INSERT INTO revoked_tokens (token_id, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (token_id) DO NOTHING;`
+
+	_, err := s.db.Exec(ctx, query, tokenID, expiresAt)
+	if err != nil {
+		log.Error(ctx, "error insert revoked token", err)
+		return pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessJWT, err)
+	}
+
+	// revoked token that already expired will be rejected by parser anyway
+	_, err = s.db.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at < NOW();`)
+	if err != nil {
+		log.Error(ctx, "error delete expired revoked token", err)
+	}
+
+	return nil
+}
+
+func (s postgresRevocationStore) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE token_id = $1)`
+
+	revoked := false
+	err := s.db.QueryRow(ctx, query, tokenID).Scan(&revoked)
+	if err != nil {
+		log.Error(ctx, "error check revoked token", err)
+		return false, pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessJWT, err)
+	}
+
+	return revoked, nil
+}
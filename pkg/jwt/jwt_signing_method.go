@@ -1,12 +1,19 @@
 package jwt
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type JWTFamily string
 
 const (
 	JWTFamilyRSA     JWTFamily = "RSA"
 	JWTFamilyHMACSHA JWTFamily = "HMAC-SHA"
+	JWTFamilyECDSA   JWTFamily = "ECDSA"
+	JWTFamilyEdDSA   JWTFamily = "EdDSA"
 )
 
 type JWTSigningMethodName string
@@ -18,6 +25,10 @@ const (
 	JWTSigningMethodNameHS256 = "HS256"
 	JWTSigningMethodNameHS384 = "HS384"
 	JWTSigningMethodNameHS512 = "HS512"
+	JWTSigningMethodNameES256 = "ES256"
+	JWTSigningMethodNameES384 = "ES384"
+	JWTSigningMethodNameES512 = "ES512"
+	JWTSigningMethodNameEdDSA = "EdDSA"
 )
 
 func (name JWTSigningMethodName) GetSigningMethod() jwt.SigningMethod {
@@ -34,6 +45,14 @@ func (name JWTSigningMethodName) GetSigningMethod() jwt.SigningMethod {
 		return jwt.SigningMethodHS384
 	case JWTSigningMethodNameHS512:
 		return jwt.SigningMethodHS512
+	case JWTSigningMethodNameES256:
+		return jwt.SigningMethodES256
+	case JWTSigningMethodNameES384:
+		return jwt.SigningMethodES384
+	case JWTSigningMethodNameES512:
+		return jwt.SigningMethodES512
+	case JWTSigningMethodNameEdDSA:
+		return jwt.SigningMethodEdDSA
 	default:
 		return nil
 	}
@@ -45,7 +64,27 @@ func (name JWTSigningMethodName) GetFamily() JWTFamily {
 		return JWTFamilyRSA
 	case JWTSigningMethodNameHS256, JWTSigningMethodNameHS384, JWTSigningMethodNameHS512:
 		return JWTFamilyHMACSHA
+	case JWTSigningMethodNameES256, JWTSigningMethodNameES384, JWTSigningMethodNameES512:
+		return JWTFamilyECDSA
+	case JWTSigningMethodNameEdDSA:
+		return JWTFamilyEdDSA
 	default:
 		return ""
 	}
 }
+
+// ParsePreviousVerificationKey parse key of rotated signing key in kid:alg:key format, e.g. key-1:ES256:-----BEGIN PUBLIC KEY-----...,
+// alg can be omitted (kid:key) and defaultMethod is returned when the key was used with the current signing method
+func ParsePreviousVerificationKey(value string, defaultMethod JWTSigningMethodName) (string, JWTSigningMethodName, string, error) {
+	keyID, key, found := strings.Cut(value, ":")
+	if !found || len(keyID) == 0 {
+		return "", "", "", fmt.Errorf("previous verification key should be in kid:alg:key format")
+	}
+
+	alg, rest, found := strings.Cut(key, ":")
+	if !found || JWTSigningMethodName(alg).GetSigningMethod() == nil {
+		return keyID, defaultMethod, key, nil
+	}
+
+	return keyID, JWTSigningMethodName(alg), rest, nil
+}
@@ -0,0 +1,82 @@
+package lockout
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pkgErr "golang-rest-api/pkg/error"
+)
+
+var (
+	ErrFailedProcessLockout = pkgErr.NewCustomError("Failed Process Lockout", "FAILED_PROCESS_LOCKOUT", http.StatusInternalServerError)
+)
+
+// Policy decide when key is locked after failed attempts,
+// lock duration is doubled on every failure after MaxAttempts up to MaxLockDuration
+type Policy struct {
+	// MaxAttempts failed attempts allowed before key is locked
+	MaxAttempts      int
+	BaseLockDuration time.Duration
+	MaxLockDuration  time.Duration
+	// ResetAfter failed attempts are forgotten when there is no failure within this duration
+	ResetAfter time.Duration
+}
+
+// LockedUntil return end of lock after failures count, zero time when key is not locked
+func (p Policy) LockedUntil(failures int, now time.Time) time.Time {
+	lockDuration := p.LockDuration(failures)
+	if lockDuration == 0 {
+		return time.Time{}
+	}
+
+	return now.Add(lockDuration)
+}
+
+// LockDuration return how long key is locked after failures count, zero when key is not locked
+func (p Policy) LockDuration(failures int) time.Duration {
+	if p.MaxAttempts <= 0 || failures < p.MaxAttempts {
+		return 0
+	}
+
+	lockDuration := p.BaseLockDuration
+	for i := p.MaxAttempts; i < failures && lockDuration < p.MaxLockDuration; i++ {
+		lockDuration *= 2
+	}
+
+	if lockDuration > p.MaxLockDuration {
+		lockDuration = p.MaxLockDuration
+	}
+
+	return lockDuration
+}
+
+// expiresAt return time when attempt record of key can be forgotten
+func (p Policy) expiresAt(lockedUntil time.Time, now time.Time) time.Time {
+	expiresAt := now.Add(p.ResetAfter)
+	if lockedUntil.After(expiresAt) {
+		return lockedUntil
+	}
+
+	return expiresAt
+}
+
+// Attempt failed attempts of a key
+type Attempt struct {
+	Failures    int
+	LockedUntil time.Time
+}
+
+// IsLocked return whether key is still locked at now
+func (a Attempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil.After(now)
+}
+
+// Store track failed attempts of key, e.g. username or client ip
+//
+//go:generate mockgen -destination=mock/store.go -package=mock golang-rest-api/pkg/lockout Store
+type Store interface {
+	Get(ctx context.Context, key string) (Attempt, error)
+	RecordFailure(ctx context.Context, key string, policy Policy) (Attempt, error)
+	Reset(ctx context.Context, key string) error
+}
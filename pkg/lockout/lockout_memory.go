@@ -0,0 +1,67 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NewInMemoryStore create store that only live in process memory,
+// should only be used for single instance deployment
+func NewInMemoryStore() *inMemoryStore {
+	return &inMemoryStore{
+		attempts:    make(map[string]inMemoryAttempt),
+		timeNowFunc: time.Now,
+	}
+}
+
+type inMemoryAttempt struct {
+	Attempt
+	expiresAt time.Time
+}
+
+type inMemoryStore struct {
+	mu          sync.Mutex
+	attempts    map[string]inMemoryAttempt
+	timeNowFunc func() time.Time
+}
+
+func (s *inMemoryStore) Get(ctx context.Context, key string) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.expiresAt.Before(s.timeNowFunc()) {
+		return Attempt{}, nil
+	}
+
+	return attempt.Attempt, nil
+}
+
+func (s *inMemoryStore) RecordFailure(ctx context.Context, key string, policy Policy) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNowFunc()
+	for k, a := range s.attempts {
+		if a.expiresAt.Before(now) {
+			delete(s.attempts, k)
+		}
+	}
+
+	attempt := s.attempts[key]
+	attempt.Failures++
+	attempt.LockedUntil = policy.LockedUntil(attempt.Failures, now)
+	attempt.expiresAt = policy.expiresAt(attempt.LockedUntil, now)
+	s.attempts[key] = attempt
+
+	return attempt.Attempt, nil
+}
+
+func (s *inMemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
@@ -0,0 +1,115 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang-rest-api/pkg/database"
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NewPostgresStore create store backed by login_attempts table,
+// can be shared across multiple instances
+func NewPostgresStore(db database.IPostgres) postgresStore {
+	return postgresStore{
+		db:          db,
+		txHandler:   database.NewTxHandler(db),
+		timeNowFunc: time.Now,
+	}
+}
+
+type postgresStore struct {
+	db          database.IPostgres
+	txHandler   database.TxHandler
+	timeNowFunc func() time.Time
+}
+
+func (s postgresStore) Get(ctx context.Context, key string) (Attempt, error) {
+	query := `SELECT failures, locked_until
+		FROM login_attempts
+		WHERE key = $1 AND expires_at > $2`
+
+	res := Attempt{}
+	var lockedUntil *time.Time
+	err := s.db.QueryRow(ctx, query, key, s.timeNowFunc()).Scan(&res.Failures, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Attempt{}, nil
+		}
+
+		log.Error(ctx, "error get login attempt", err)
+		return Attempt{}, pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessLockout, err)
+	}
+
+	if lockedUntil != nil {
+		res.LockedUntil = *lockedUntil
+	}
+
+	return res, nil
+}
+
+func (s postgresStore) RecordFailure(ctx context.Context, key string, policy Policy) (Attempt, error) {
+	now := s.timeNowFunc()
+
+	// failures of expired record start again from 1
+	query := `INSERT INTO login_attempts (key, failures, expires_at)
+		VALUES ($1, 1, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE WHEN login_attempts.expires_at <= $2 THEN 1 ELSE login_attempts.failures + 1 END,
+			locked_until = NULL,
+			expires_at = $3
+		RETURNING failures`
+
+	res := Attempt{}
+	// counting and locking run in one transaction, so concurrent Get never see the key unlocked in between
+	err := s.txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, query, key, now, policy.expiresAt(time.Time{}, now)).Scan(&res.Failures)
+		if err != nil {
+			log.Error(ctx, "error record login failure", err)
+			return pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessLockout, err)
+		}
+
+		res.LockedUntil = policy.LockedUntil(res.Failures, now)
+		if res.LockedUntil.IsZero() {
+			return nil
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE login_attempts SET locked_until = $2, expires_at = $3 WHERE key = $1`,
+			key,
+			res.LockedUntil,
+			policy.expiresAt(res.LockedUntil, now),
+		)
+		if err != nil {
+			log.Error(ctx, "error lock login attempt", err)
+			return pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessLockout, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	_, err = s.db.Exec(ctx, `DELETE FROM login_attempts WHERE expires_at < $1;`, now)
+	if err != nil {
+		log.Error(ctx, "error delete expired login attempt", err)
+	}
+
+	return res, nil
+}
+
+func (s postgresStore) Reset(ctx context.Context, key string) error {
+	_, err := s.db.Exec(ctx, `DELETE FROM login_attempts WHERE key = $1;`, key)
+	if err != nil {
+		log.Error(ctx, "error reset login attempt", err)
+		return pkgErr.NewCustomErrWithOriginalErr(ErrFailedProcessLockout, err)
+	}
+
+	return nil
+}
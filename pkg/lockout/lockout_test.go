@@ -0,0 +1,53 @@
+package lockout
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyLockedUntil(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := Policy{
+		MaxAttempts:      3,
+		BaseLockDuration: time.Minute,
+		MaxLockDuration:  10 * time.Minute,
+		ResetAfter:       time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		failures int
+		want     time.Time
+	}{
+		{name: "no failure", policy: policy, failures: 0, want: time.Time{}},
+		{name: "below max attempts", policy: policy, failures: 2, want: time.Time{}},
+		{name: "reach max attempts", policy: policy, failures: 3, want: now.Add(time.Minute)},
+		{name: "doubled after max attempts", policy: policy, failures: 4, want: now.Add(2 * time.Minute)},
+		{name: "doubled twice", policy: policy, failures: 5, want: now.Add(4 * time.Minute)},
+		{name: "doubled three times", policy: policy, failures: 6, want: now.Add(8 * time.Minute)},
+		{name: "clamped to max lock duration", policy: policy, failures: 7, want: now.Add(10 * time.Minute)},
+		{name: "stay clamped", policy: policy, failures: 100, want: now.Add(10 * time.Minute)},
+		{
+			name:     "base above max lock duration",
+			policy:   Policy{MaxAttempts: 1, BaseLockDuration: time.Hour, MaxLockDuration: time.Minute},
+			failures: 1,
+			want:     now.Add(time.Minute),
+		},
+		{
+			name:     "zero max attempts disable lock",
+			policy:   Policy{MaxAttempts: 0, BaseLockDuration: time.Minute, MaxLockDuration: time.Hour},
+			failures: 100,
+			want:     time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.LockedUntil(tt.failures, now)
+			if !got.Equal(tt.want) {
+				t.Errorf("LockedUntil(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+)
+
+// NewFileMailer create mailer which write every mail as .eml file in outbox directory
+// instead of delivering it, should only be used for local development
+func NewFileMailer(outboxDir string) fileMailer {
+	return fileMailer{
+		outboxDir:   outboxDir,
+		timeNowFunc: time.Now,
+	}
+}
+
+type fileMailer struct {
+	outboxDir   string
+	timeNowFunc func() time.Time
+}
+
+func (m fileMailer) Send(ctx context.Context, mail Mail) error {
+	err := os.MkdirAll(m.outboxDir, 0o755)
+	if err != nil {
+		log.Error(ctx, "error create mail outbox directory", err)
+		return pkgErr.NewCustomErrWithOriginalErr(ErrFailedSendMail, err)
+	}
+
+	now := m.timeNowFunc()
+	fileName := filepath.Join(m.outboxDir, fmt.Sprintf("%d.eml", now.UnixNano()))
+	content := fmt.Sprintf(
+		"Date: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		now.Format(time.RFC1123Z),
+		strings.Join(mail.To, ", "),
+		mail.Subject,
+		mail.Body,
+	)
+
+	err = os.WriteFile(fileName, []byte(content), 0o600)
+	if err != nil {
+		log.Error(ctx, "error write mail to outbox", err)
+		return pkgErr.NewCustomErrWithOriginalErr(ErrFailedSendMail, err)
+	}
+
+	log.Info(ctx, fmt.Sprintf("mail %q written to %s", mail.Subject, fileName))
+	return nil
+}
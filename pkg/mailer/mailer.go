@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"context"
+	"net/http"
+
+	pkgErr "golang-rest-api/pkg/error"
+)
+
+var (
+	ErrFailedSendMail = pkgErr.NewCustomError("Failed Send Mail", "FAILED_SEND_MAIL", http.StatusInternalServerError)
+)
+
+type Mail struct {
+	To      []string
+	Subject string
+	// Body plain text body of the mail
+	Body string
+}
+
+//go:generate mockgen -destination=mock/mailer.go -package=mock golang-rest-api/pkg/mailer Mailer
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
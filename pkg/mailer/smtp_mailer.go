@@ -0,0 +1,85 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	pkgErr "golang-rest-api/pkg/error"
+	"golang-rest-api/pkg/log"
+)
+
+type SMTPMailerOption func(*smtpMailer)
+
+func WithSMTPHost(host string) SMTPMailerOption {
+	return func(m *smtpMailer) {
+		m.host = host
+	}
+}
+
+func WithSMTPPort(port string) SMTPMailerOption {
+	return func(m *smtpMailer) {
+		m.port = port
+	}
+}
+
+// WithSMTPAuth assign credential for SMTP PLAIN authentication
+func WithSMTPAuth(username string, password string) SMTPMailerOption {
+	return func(m *smtpMailer) {
+		m.username = username
+		m.password = password
+	}
+}
+
+func WithSMTPFrom(from string) SMTPMailerOption {
+	return func(m *smtpMailer) {
+		m.from = from
+	}
+}
+
+func NewSMTPMailer(options ...SMTPMailerOption) smtpMailer {
+	m := &smtpMailer{
+		port:     "587",
+		sendMail: smtp.SendMail,
+	}
+
+	for _, apply := range options {
+		apply(m)
+	}
+
+	return *m
+}
+
+type smtpMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (m smtpMailer) Send(ctx context.Context, mail Mail) error {
+	var auth smtp.Auth
+	if len(m.username) > 0 {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\n%s\r\n",
+		m.from,
+		strings.Join(mail.To, ", "),
+		mail.Subject,
+		mail.Body,
+	)
+
+	err := m.sendMail(net.JoinHostPort(m.host, m.port), auth, m.from, mail.To, []byte(msg))
+	if err != nil {
+		log.Error(ctx, "error send mail via smtp", err)
+		return pkgErr.NewCustomErrWithOriginalErr(ErrFailedSendMail, err)
+	}
+
+	return nil
+}
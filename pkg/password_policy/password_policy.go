@@ -0,0 +1,121 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"context"
+	pkgErr "golang-rest-api/pkg/error"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	ErrorPasswordPolicyViolated = pkgErr.NewCustomError("password does not meet password policy", "PASSWORD_POLICY_VIOLATED", http.StatusBadRequest)
+)
+
+// violation code reported in details of ErrorPasswordPolicyViolated
+const (
+	ViolationTooShort         = "PASSWORD_TOO_SHORT"
+	ViolationMissingUpper     = "PASSWORD_MISSING_UPPER"
+	ViolationMissingLower     = "PASSWORD_MISSING_LOWER"
+	ViolationMissingDigit     = "PASSWORD_MISSING_DIGIT"
+	ViolationMissingSymbol    = "PASSWORD_MISSING_SYMBOL"
+	ViolationContainsUsername = "PASSWORD_CONTAINS_USERNAME"
+	ViolationDenied           = "PASSWORD_DENIED"
+)
+
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+//go:generate mockgen -destination=mock/password_policy.go -package=mock golang-rest-api/pkg/password_policy PasswordPolicy
+type PasswordPolicy interface {
+	// Check return ErrorPasswordPolicyViolated with []Violation details when password violate the policy,
+	// username can be empty when it is not known
+	Check(ctx context.Context, password string, username string) error
+}
+
+type PasswordPolicyOption func(*passwordPolicy)
+
+// WithStrengthRule assign minimum length and character classes which password must contain
+func WithStrengthRule(rule StrengthRule) PasswordPolicyOption {
+	return func(p *passwordPolicy) {
+		p.strengthRule = rule
+	}
+}
+
+// WithDenyList reject password which is in passwords, compared case insensitively
+func WithDenyList(passwords []string) PasswordPolicyOption {
+	return func(p *passwordPolicy) {
+		p.denyList = make(map[string]struct{}, len(passwords))
+		for _, password := range passwords {
+			p.denyList[strings.ToLower(password)] = struct{}{}
+		}
+	}
+}
+
+// NewPasswordPolicy create policy which require DefaultStrengthRule and reject password containing username
+func NewPasswordPolicy(options ...PasswordPolicyOption) passwordPolicy {
+	p := &passwordPolicy{
+		strengthRule: DefaultStrengthRule(),
+		denyList:     map[string]struct{}{},
+	}
+
+	for _, apply := range options {
+		apply(p)
+	}
+
+	return *p
+}
+
+type passwordPolicy struct {
+	strengthRule StrengthRule
+	denyList     map[string]struct{}
+}
+
+func (p passwordPolicy) Check(ctx context.Context, password string, username string) error {
+	violations := p.strengthRule.Violations(password)
+
+	lowerPassword := strings.ToLower(password)
+	if len(username) > 0 && strings.Contains(lowerPassword, strings.ToLower(username)) {
+		violations = append(violations, Violation{Code: ViolationContainsUsername, Message: "must not contain username"})
+	}
+
+	if _, ok := p.denyList[lowerPassword]; ok {
+		violations = append(violations, Violation{Code: ViolationDenied, Message: "is too common or has appeared in a data breach"})
+	}
+
+	if len(violations) > 0 {
+		return pkgErr.NewCustomErrWithDetails(ErrorPasswordPolicyViolated, violations)
+	}
+
+	return nil
+}
+
+// LoadDenyListFile read password deny list containing one password per line,
+// empty line and line starting with # are ignored
+func LoadDenyListFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	passwords := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		passwords = append(passwords, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return passwords, nil
+}
@@ -0,0 +1,66 @@
+package passwordpolicy
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// StrengthRule minimum length in characters and character classes which password must contain
+type StrengthRule struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// DefaultStrengthRule require at least 8 characters with upper case letter, lower case letter and digit
+func DefaultStrengthRule() StrengthRule {
+	return StrengthRule{
+		MinLength:    8,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}
+}
+
+// Violations return every part of the rule which password does not meet
+func (r StrengthRule) Violations(password string) []Violation {
+	violations := []Violation{}
+
+	if len([]rune(password)) < r.MinLength {
+		violations = append(violations, Violation{
+			Code:    ViolationTooShort,
+			Message: fmt.Sprintf("must be at least %d characters", r.MinLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			hasUpper = true
+		case unicode.IsLower(c):
+			hasLower = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c):
+			hasSymbol = true
+		}
+	}
+
+	if r.RequireUpper && !hasUpper {
+		violations = append(violations, Violation{Code: ViolationMissingUpper, Message: "must contain an upper case letter"})
+	}
+	if r.RequireLower && !hasLower {
+		violations = append(violations, Violation{Code: ViolationMissingLower, Message: "must contain a lower case letter"})
+	}
+	if r.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{Code: ViolationMissingDigit, Message: "must contain a digit"})
+	}
+	if r.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{Code: ViolationMissingSymbol, Message: "must contain a symbol"})
+	}
+
+	return violations
+}
@@ -0,0 +1,125 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+//go:generate mockgen -destination=mock/totp.go -package=mock golang-rest-api/pkg/totp TOTP
+type TOTP interface {
+	GenerateSecret() (string, error)
+	KeyURI(accountName string, secret string) string
+	// Validate return time step counter of matching code,
+	// caller should reject counter which is not greater than the last used one to prevent replay
+	Validate(secret string, code string) (int64, bool)
+}
+
+type TOTPOption func(*totp)
+
+func WithIssuer(issuer string) TOTPOption {
+	return func(t *totp) {
+		t.issuer = issuer
+	}
+}
+
+// WithSkew assign number of time step before and after current time step which code is still accepted
+func WithSkew(skew int64) TOTPOption {
+	return func(t *totp) {
+		t.skew = skew
+	}
+}
+
+// NewTOTP create RFC 6238 TOTP using HMAC-SHA1, 6 digits and 30 seconds period
+// which is supported by most authenticator app
+func NewTOTP(options ...TOTPOption) totp {
+	t := &totp{
+		period:      30,
+		digits:      6,
+		skew:        1,
+		timeNowFunc: time.Now,
+	}
+
+	for _, apply := range options {
+		apply(t)
+	}
+
+	return *t
+}
+
+type totp struct {
+	issuer      string
+	period      int64
+	digits      int
+	skew        int64
+	timeNowFunc func() time.Time
+}
+
+// GenerateSecret return random 160 bits secret in base32 without padding
+func (t totp) GenerateSecret() (string, error) {
+	b := make([]byte, 20)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// KeyURI return otpauth uri which is usually shown as QR code to be scanned by authenticator app
+func (t totp) KeyURI(accountName string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", t.issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(t.digits))
+	params.Set("period", fmt.Sprint(t.period))
+
+	label := url.PathEscape(t.issuer + ":" + accountName)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+func (t totp) Validate(secret string, code string) (int64, bool) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != t.digits {
+		return 0, false
+	}
+
+	current := t.timeNowFunc().Unix() / t.period
+	for counter := current - t.skew; counter <= current+t.skew; counter++ {
+		if subtle.ConstantTimeCompare([]byte(t.code(key, counter)), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
+
+// code generate HOTP value of counter as described in RFC 4226
+func (t totp) code(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < t.digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.digits, value%mod)
+}
@@ -0,0 +1,96 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret base32 of the SHA1 seed "12345678901234567890" used by RFC 6238 Appendix B
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateRFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix        int64
+		code        string
+		wantCounter int64
+	}{
+		{unix: 59, code: "94287082", wantCounter: 1},
+		{unix: 1111111109, code: "07081804", wantCounter: 37037036},
+		{unix: 1111111111, code: "14050471", wantCounter: 37037037},
+		{unix: 1234567890, code: "89005924", wantCounter: 41152263},
+		{unix: 2000000000, code: "69279037", wantCounter: 66666666},
+		{unix: 20000000000, code: "65353130", wantCounter: 666666666},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			now := time.Unix(tt.unix, 0)
+			otp := totp{period: 30, digits: 8, skew: 0, timeNowFunc: func() time.Time { return now }}
+
+			counter, ok := otp.Validate(rfc6238Secret, tt.code)
+			if !ok || counter != tt.wantCounter {
+				t.Errorf("Validate(%q) at %d = %d, %v, want %d, true", tt.code, tt.unix, counter, ok, tt.wantCounter)
+			}
+		})
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	// 6 digits code of time step 1, the last 6 digits of RFC 6238 vector at T=59
+	const code = "287082"
+
+	tests := []struct {
+		name        string
+		unix        int64
+		skew        int64
+		wantCounter int64
+		wantOK      bool
+	}{
+		{name: "current step", unix: 59, skew: 1, wantCounter: 1, wantOK: true},
+		{name: "one step before", unix: 89, skew: 1, wantCounter: 1, wantOK: true},
+		{name: "one step after", unix: 29, skew: 1, wantCounter: 1, wantOK: true},
+		{name: "two steps before", unix: 119, skew: 1, wantOK: false},
+		{name: "one step after without skew", unix: 29, skew: 0, wantOK: false},
+		{name: "one step before without skew", unix: 89, skew: 0, wantOK: false},
+		{name: "two steps before with skew 2", unix: 119, skew: 2, wantCounter: 1, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(tt.unix, 0)
+			otp := NewTOTP(WithSkew(tt.skew))
+			otp.timeNowFunc = func() time.Time { return now }
+
+			counter, ok := otp.Validate(rfc6238Secret, code)
+			if ok != tt.wantOK || counter != tt.wantCounter {
+				t.Errorf("Validate at %d = %d, %v, want %d, %v", tt.unix, counter, ok, tt.wantCounter, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateRejectMalformed(t *testing.T) {
+	now := time.Unix(59, 0)
+	otp := NewTOTP()
+	otp.timeNowFunc = func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{name: "empty code", secret: rfc6238Secret, code: ""},
+		{name: "code too short", secret: rfc6238Secret, code: "28708"},
+		{name: "code too long", secret: rfc6238Secret, code: "2870820"},
+		{name: "invalid secret", secret: "not base32!", code: "287082"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := otp.Validate(tt.secret, tt.code)
+			if ok {
+				t.Errorf("Validate(%q, %q) = true, want false", tt.secret, tt.code)
+			}
+		})
+	}
+}
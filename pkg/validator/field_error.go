@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pkgErr "golang-rest-api/pkg/error"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrorPayloadNotValid    = pkgErr.NewCustomError("payload not valid", "PAYLOAD_NOT_VALID", http.StatusBadRequest)
+	ErrorQueryParamNotValid = pkgErr.NewCustomError("query param not valid", "QUERY_PARAM_NOT_VALID", http.StatusBadRequest)
+)
+
+// FieldError reason of a field failing validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidateStruct validate request body, return ErrorPayloadNotValid with []FieldError details
+func ValidateStruct(ctx context.Context, s any) error {
+	return validateStruct(ctx, s, ErrorPayloadNotValid)
+}
+
+// ValidateQuery validate request query param, return ErrorQueryParamNotValid with []FieldError details
+func ValidateQuery(ctx context.Context, s any) error {
+	return validateStruct(ctx, s, ErrorQueryParamNotValid)
+}
+
+func validateStruct(ctx context.Context, s any, baseErr pkgErr.CustomError) error {
+	err := Validate.StructCtx(ctx, s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return pkgErr.NewCustomErrWithOriginalErr(baseErr, err)
+	}
+
+	fieldErrs := make([]FieldError, 0, len(validationErrs))
+	for _, e := range validationErrs {
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:   fieldPath(e),
+			Rule:    e.Tag(),
+			Message: fieldErrorMessage(e),
+		})
+	}
+
+	return pkgErr.NewCustomErrWithDetails(pkgErr.NewCustomErrWithOriginalErr(baseErr, err), fieldErrs)
+}
+
+// fieldPath return json path of field without the root struct name, e.g. address.city
+func fieldPath(e validator.FieldError) string {
+	namespace := e.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return e.Field()
+}
+
+func fieldErrorMessage(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", e.Param())
+	case "nefield":
+		return fmt.Sprintf("must be different from %s", e.Param())
+	case "min", "max", "len":
+		return lengthMessage(e)
+	case "phone":
+		return "must be E.164 phone number, e.g. +6281234567890"
+	case "max_bytes":
+		return fmt.Sprintf("must be at most %s bytes", e.Param())
+	case "username":
+		return "must be 3 to 100 letters, digits, dot, underscore or dash and start with letter or digit"
+	case "password_strength":
+		return passwordStrengthMessage(e)
+	default:
+		return fmt.Sprintf("failed on %s rule", e.Tag())
+	}
+}
+
+func lengthMessage(e validator.FieldError) string {
+	bound := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[e.Tag()]
+
+	switch e.Kind().String() {
+	case "string":
+		return fmt.Sprintf("must be %s %s characters", bound, e.Param())
+	case "slice", "map", "array":
+		return fmt.Sprintf("must contain %s %s items", bound, e.Param())
+	default:
+		return fmt.Sprintf("must be %s %s", bound, e.Param())
+	}
+}
+
+// passwordStrengthMessage join every part of the strength rule which password does not meet
+func passwordStrengthMessage(e validator.FieldError) string {
+	password, _ := e.Value().(string)
+
+	messages := []string{}
+	for _, v := range passwordStrengthRule.Violations(password) {
+		messages = append(messages, v.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
@@ -0,0 +1,60 @@
+package validator
+
+import (
+	passwordpolicy "golang-rest-api/pkg/password_policy"
+	"regexp"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// phoneRegex E.164 number limited to 15 characters including + to fit users.phone column
+	phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{1,13}$`)
+	// usernameRegex 3 to 100 characters to fit users.username column, start with letter or digit
+	usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{2,99}$`)
+	// passwordStrengthRule rule checked by password_strength, replaced by SetPasswordStrengthRule
+	passwordStrengthRule = passwordpolicy.DefaultStrengthRule()
+)
+
+// SetPasswordStrengthRule assign rule checked by password_strength,
+// call it on startup with the rule given to the password policy so both reject the same password
+func SetPasswordStrengthRule(rule passwordpolicy.StrengthRule) {
+	passwordStrengthRule = rule
+}
+
+func registerRules(v *validator.Validate) {
+	_ = v.RegisterValidation("phone", validatePhone)
+	_ = v.RegisterValidation("username", validateUsername)
+	_ = v.RegisterValidation("max_bytes", validateMaxBytes)
+	_ = v.RegisterValidation("password_strength", validatePasswordStrength)
+}
+
+// validatePhone empty phone is valid, use required to require it
+func validatePhone(fl validator.FieldLevel) bool {
+	phone := fl.Field().String()
+	if len(phone) == 0 {
+		return true
+	}
+
+	return phoneRegex.MatchString(phone)
+}
+
+func validateUsername(fl validator.FieldLevel) bool {
+	return usernameRegex.MatchString(fl.Field().String())
+}
+
+// validateMaxBytes limit length of string in bytes instead of characters, e.g. max_bytes=72
+func validateMaxBytes(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		return false
+	}
+
+	return len(fl.Field().String()) <= limit
+}
+
+// validatePasswordStrength password must meet passwordStrengthRule
+func validatePasswordStrength(fl validator.FieldLevel) bool {
+	return len(passwordStrengthRule.Violations(fl.Field().String())) == 0
+}
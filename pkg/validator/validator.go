@@ -25,5 +25,7 @@ func init() {
 
 			return field.Tag.Get("name")
 		})
+
+		registerRules(Validate)
 	})
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"golang-rest-api/config"
+	modelRole "golang-rest-api/internal/model/role"
+	modelUser "golang-rest-api/internal/model/user"
+	repoRole "golang-rest-api/internal/repository/role"
+	repoUser "golang-rest-api/internal/repository/user"
+	"golang-rest-api/pkg/crypter"
+	"golang-rest-api/pkg/database"
+	"golang-rest-api/pkg/log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// bootstrap the first admin, which then assign role to other users through the role API
+func main() {
+	config.LoadEnvConfig()
+
+	log.InitLogger(log.LoggerMetaData{
+		LogLevel:   "INFO",
+		Service:    "script_seed_admin",
+		AppVersion: "v0.0.0",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	username := os.Getenv("SEED_ADMIN_USERNAME")
+	password := os.Getenv("SEED_ADMIN_PASSWORD")
+	if len(username) == 0 || len(password) == 0 {
+		log.Fatal(ctx, "error seed admin", fmt.Errorf("SEED_ADMIN_USERNAME and SEED_ADMIN_PASSWORD are required"))
+	}
+
+	db := database.NewPostgres(
+		database.WithPostgresDBHost(config.Get().DatabaseHost),
+		database.WithPostgresDBPort(config.Get().DatabasePort),
+		database.WithPostgresDBUser(config.Get().DatabaseUser),
+		database.WithPostgresDBPassword(config.Get().DatabasePass),
+		database.WithPostgresDBName(config.Get().DatabaseName),
+	)
+
+	txHandler := database.NewTxHandler(db)
+	userRepo := repoUser.NewUserRepo(db)
+	roleRepo := repoRole.NewRoleRepo(db)
+
+	// hash using the same parameters as the API so the password is not rehashed on first login
+	passwordHash, err := crypter.New(
+		crypter.WithAlgorithm(config.Get().PasswordHashAlgorithm),
+		crypter.WithBcryptCost(config.Get().BcryptCost),
+		crypter.WithArgon2Params(crypter.Argon2Params{
+			Memory:      config.Get().Argon2Memory,
+			Iterations:  config.Get().Argon2Iterations,
+			Parallelism: config.Get().Argon2Parallelism,
+			SaltLength:  16,
+			KeyLength:   32,
+		}),
+	).GenerateHash(ctx, password)
+	if err != nil {
+		log.Fatal(ctx, "error seed admin", err)
+	}
+
+	adminRole, err := roleRepo.GetRoleByName(ctx, modelRole.RoleAdmin)
+	if err != nil {
+		log.Fatal(ctx, "error seed admin", err)
+	}
+
+	userID := uuid.NewString()
+	err = txHandler.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := userRepo.CreateUserTx(ctx, tx, modelUser.InsertUser{
+			ID:       userID,
+			Name:     username,
+			Username: username,
+			Password: string(passwordHash),
+			Actor:    "seed_admin",
+		})
+		if err != nil {
+			return err
+		}
+
+		return roleRepo.AssignUserRoleTx(ctx, tx, modelRole.InsertUserRole{
+			UserID: userID,
+			RoleID: adminRole.ID,
+			Actor:  "seed_admin",
+		})
+	})
+	if err != nil {
+		log.Fatal(ctx, "error seed admin", err)
+	}
+
+	log.Info(ctx, "success seeding admin")
+}